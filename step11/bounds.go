@@ -0,0 +1,249 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Априорные оценки спектра
+//
+// Теорема Гершгорина: все собственные значения лежат в объединении
+// кругов с центрами A[i][i] и радиусами Σ |A[i][j]|, j != i.
+// То же верно для кругов по столбцам. Если объединение k кругов
+// не пересекается с остальными кругами, то в нем ровно k
+// собственных значений.
+//
+// Спектральный радиус не больше любой нормы матрицы:
+//
+//	ρ(A) <= ||A||₁, ||A||∞, ||A||F
+
+// доступ к ненулевым элементам матрицы
+type entries interface {
+	each(f func(row, col int, val float64))
+}
+
+func (A dense) each(f func(row, col int, val float64)) {
+	for row := range A {
+		for col := range A[row] {
+			if A[row][col] != 0.0 {
+				f(row, col, A[row][col])
+			}
+		}
+	}
+}
+
+// повторные значения одной ячейки суммируются
+func (s *sparse) each(f func(row, col int, val float64)) {
+	type cell struct{ row, col int }
+	sum := map[cell]float64{}
+	var order []cell
+	for k := range s.val {
+		c := cell{row: s.row[k], col: s.col[k]}
+		if _, ok := sum[c]; !ok {
+			order = append(order, c)
+		}
+		sum[c] += s.val[k]
+	}
+	for _, c := range order {
+		f(c.row, c.col, sum[c])
+	}
+}
+
+// круг Гершгорина
+type disc struct {
+	center float64
+	radius float64
+}
+
+// связная компонента объединения кругов
+type component struct {
+	// границы компоненты на вещественной оси
+	lower, upper float64
+
+	// индексы кругов компоненты
+	discs []int
+
+	// количество собственных значений внутри компоненты
+	count int
+}
+
+// круги Гершгорина по строкам или столбцам
+type gershgorin struct {
+	discs      []disc
+	components []component
+}
+
+// границы объединения кругов на вещественной оси
+func (g gershgorin) interval() (lower, upper float64) {
+	lower, upper = math.MaxFloat64, -math.MaxFloat64
+	for _, c := range g.components {
+		lower = math.Min(lower, c.lower)
+		upper = math.Max(upper, c.upper)
+	}
+	return
+}
+
+// проверка попадания собственного значения в объединение кругов
+func (g gershgorin) contains(l float64) bool {
+	for _, d := range g.discs {
+		if math.Abs(l-d.center) <= d.radius*(1+𝛆*100)+𝛆*100*math.Abs(d.center) {
+			return true
+		}
+	}
+	return false
+}
+
+// априорные оценки спектра
+type bounds struct {
+	// круги по строкам и по столбцам
+	rows, cols gershgorin
+
+	// ||A||₁ - наибольшая сумма модулей столбца
+	norm1 float64
+
+	// ||A||∞ - наибольшая сумма модулей строки
+	normInf float64
+
+	// ||A||F - норма Фробениуса
+	normF float64
+
+	// оценка сверху спектрального радиуса
+	radius float64
+}
+
+// вещественный интервал собственных значений как пересечение
+// интервалов кругов по строкам и по столбцам
+func (b bounds) interval() (lower, upper float64) {
+	rowLower, rowUpper := b.rows.interval()
+	colLower, colUpper := b.cols.interval()
+	return math.Max(rowLower, colLower), math.Min(rowUpper, colUpper)
+}
+
+func spectralBounds(A operator) (b bounds, err error) {
+	n := A.size()
+	if n == 0 {
+		err = fmt.Errorf("matrix size is zero")
+		return
+	}
+	en, ok := A.(entries)
+	if !ok {
+		err = fmt.Errorf("operator %T has no access to elements", A)
+		return
+	}
+
+	center := make([]float64, n)
+	rowSum := make([]float64, n)
+	colSum := make([]float64, n)
+	en.each(func(row, col int, val float64) {
+		if row == col {
+			center[row] += val
+		} else {
+			rowSum[row] += math.Abs(val)
+			colSum[col] += math.Abs(val)
+		}
+		b.normF += val * val
+	})
+	b.normF = math.Sqrt(b.normF)
+
+	b.rows.discs = make([]disc, n)
+	b.cols.discs = make([]disc, n)
+	for i := 0; i < n; i++ {
+		b.rows.discs[i] = disc{center: center[i], radius: rowSum[i]}
+		b.cols.discs[i] = disc{center: center[i], radius: colSum[i]}
+		b.normInf = math.Max(b.normInf, math.Abs(center[i])+rowSum[i])
+		b.norm1 = math.Max(b.norm1, math.Abs(center[i])+colSum[i])
+	}
+	b.rows.components = discComponents(b.rows.discs)
+	b.cols.components = discComponents(b.cols.discs)
+
+	b.radius = math.Min(b.norm1, math.Min(b.normInf, b.normF))
+	return
+}
+
+// Связные компоненты объединения кругов.
+// Центры кругов вещественные, поэтому круги пересекаются
+// тогда и только тогда, когда пересекаются их отрезки
+// на вещественной оси.
+func discComponents(discs []disc) (cs []component) {
+	index := make([]int, len(discs))
+	for i := range index {
+		index[i] = i
+	}
+	sort.SliceStable(index, func(i, j int) bool {
+		di, dj := discs[index[i]], discs[index[j]]
+		return di.center-di.radius < dj.center-dj.radius
+	})
+	for _, i := range index {
+		d := discs[i]
+		lower, upper := d.center-d.radius, d.center+d.radius
+		if last := len(cs) - 1; last >= 0 && lower <= cs[last].upper {
+			cs[last].upper = math.Max(cs[last].upper, upper)
+			cs[last].discs = append(cs[last].discs, i)
+			cs[last].count++
+			continue
+		}
+		cs = append(cs, component{
+			lower: lower,
+			upper: upper,
+			discs: []int{i},
+			count: 1,
+		})
+	}
+	return
+}
+
+// проверять результаты exh и exhSelect по априорным оценкам
+var boundsCheck bool = false
+
+// Проверка результата решателя по априорным оценкам
+// при отладке, вывод на экран проверку не включает
+func debugBounds(A [][]float64, e []eigen) (err error) {
+	if !boundsCheck {
+		return
+	}
+	b, err := spectralBounds(dense(A))
+	if err != nil {
+		return
+	}
+	return checkBounds(b, e)
+}
+
+// проверка найденных собственных значений по априорным оценкам
+func checkBounds(b bounds, e []eigen) (err error) {
+	for i := range e {
+		l := e[i].𝜦
+		if math.Abs(l) > b.radius*(1+𝛆*100) {
+			err = fmt.Errorf("eigenvalue %d is outside of spectral radius: |%.14e| > %.14e",
+				i, l, b.radius)
+			return
+		}
+		if !b.rows.contains(l) || !b.cols.contains(l) {
+			err = fmt.Errorf("eigenvalue %d is outside of Gershgorin discs: %.14e", i, l)
+			return
+		}
+	}
+
+	// количество собственных значений в компонентах
+	// проверяется только для полного спектра
+	if len(e) != len(b.rows.discs) {
+		return
+	}
+	for _, g := range []gershgorin{b.rows, b.cols} {
+		for _, c := range g.components {
+			var count int
+			for i := range e {
+				if c.lower-𝛆*100*math.Abs(c.lower) <= e[i].𝜦 &&
+					e[i].𝜦 <= c.upper+𝛆*100*math.Abs(c.upper) {
+					count++
+				}
+			}
+			if count != c.count {
+				err = fmt.Errorf("amount of eigenvalues in [%.5e, %.5e]: %d != %d",
+					c.lower, c.upper, count, c.count)
+				return
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBounds(t *testing.T) {
+	A := [][]float64{
+		{4, 1, 0},
+		{1, -2, 0.5},
+		{0, 2, 10},
+	}
+
+	// та же матрица в разреженном виде с повторными ячейками
+	s := &sparse{n: 3}
+	s.add(0, 0, 3)
+	s.add(0, 0, 1)
+	s.add(0, 1, 1)
+	s.add(1, 0, 1)
+	s.add(1, 1, -2)
+	s.add(1, 2, 1.5)
+	s.add(1, 2, -1)
+	s.add(2, 1, 2)
+	s.add(2, 2, 10)
+
+	for _, tc := range []struct {
+		name string
+		A    operator
+	}{
+		{name: "dense", A: dense(A)},
+		{name: "sparse", A: s},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := spectralBounds(tc.A)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rows := []disc{{4, 1}, {-2, 1.5}, {10, 2}}
+			cols := []disc{{4, 1}, {-2, 3}, {10, 0.5}}
+			for i := range rows {
+				if b.rows.discs[i] != rows[i] {
+					t.Errorf("row disc %d: %v != %v", i, b.rows.discs[i], rows[i])
+				}
+				if b.cols.discs[i] != cols[i] {
+					t.Errorf("column disc %d: %v != %v", i, b.cols.discs[i], cols[i])
+				}
+			}
+
+			for _, c := range []struct {
+				g            gershgorin
+				lower, upper []float64
+			}{
+				{g: b.rows, lower: []float64{-3.5, 3, 8}, upper: []float64{-0.5, 5, 12}},
+				{g: b.cols, lower: []float64{-5, 3, 9.5}, upper: []float64{1, 5, 10.5}},
+			} {
+				if len(c.g.components) != len(c.lower) {
+					t.Fatalf("amount of components: %d", len(c.g.components))
+				}
+				for i, comp := range c.g.components {
+					if comp.lower != c.lower[i] || comp.upper != c.upper[i] || comp.count != 1 {
+						t.Errorf("component %d: %v", i, comp)
+					}
+				}
+			}
+
+			if lower, upper := b.interval(); lower != -3.5 || upper != 10.5 {
+				t.Errorf("interval: [%v, %v]", lower, upper)
+			}
+
+			if b.norm1 != 10.5 || b.normInf != 12 ||
+				math.Abs(b.normF-math.Sqrt(126.25)) > 1e-14 || b.radius != 10.5 {
+				t.Errorf("norms: %v", b)
+			}
+		})
+	}
+
+	t.Run("components", func(t *testing.T) {
+		b, err := spectralBounds(dense([][]float64{
+			{1, 0.5, 0, 0},
+			{0.5, 2, 0, 0},
+			{0, 0, 10, 1},
+			{0, 0, 1, 11},
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		cs := b.rows.components
+		if len(cs) != 2 || cs[0].count != 2 || cs[1].count != 2 {
+			t.Fatalf("components: %v", cs)
+		}
+	})
+
+	t.Run("matrix-free", func(t *testing.T) {
+		if _, err := spectralBounds(laplacian(5)); err == nil {
+			t.Errorf("error is not found")
+		}
+	})
+}
+
+func TestCheckBounds(t *testing.T) {
+	A := randomSymmetric(6, 1)
+	e, err := jacobi(A, cyclicSweep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := spectralBounds(dense(A))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkBounds(b, e); err != nil {
+		t.Error(err)
+	}
+
+	// собственное значение вне кругов
+	wrong := append([]eigen{}, e...)
+	wrong[0].𝜦 = 2 * b.radius
+	if err := checkBounds(b, wrong); err == nil {
+		t.Errorf("error is not found")
+	} else {
+		t.Log(err)
+	}
+
+	// собственное значение в другой компоненте
+	b, err = spectralBounds(dense([][]float64{
+		{1, 0.5, 0, 0},
+		{0.5, 2, 0, 0},
+		{0, 0, 10, 1},
+		{0, 0, 1, 11},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkBounds(b, []eigen{{𝜦: 1}, {𝜦: 2}, {𝜦: 2.5}, {𝜦: 11}}); err == nil {
+		t.Errorf("error is not found")
+	} else {
+		t.Log(err)
+	}
+}
+
+func TestDebugBounds(t *testing.T) {
+	old := boundsCheck
+	boundsCheck = true
+	defer func() {
+		boundsCheck = old
+	}()
+	defer harmonicStart()()
+
+	A := randomSymmetric(6, 1)
+	if _, err := exhSelect(A, selector{which: largestMagnitude}); err != nil {
+		t.Fatal(err)
+	}
+	oldDeflation := deflation
+	deflation = implicit
+	_, err := exh(A)
+	deflation = oldDeflation
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := debugBounds(A, []eigen{{𝜦: 1e3}}); err == nil {
+		t.Errorf("error is not found")
+	}
+	boundsCheck = false
+	if err := debugBounds(A, []eigen{{𝜦: 1e3}}); err != nil {
+		t.Errorf("check without debug: %v", err)
+	}
+	oldOutput := output
+	output = true
+	err = debugBounds(A, []eigen{{𝜦: 1e3}})
+	output = oldOutput
+	if err != nil {
+		t.Errorf("check with output: %v", err)
+	}
+}
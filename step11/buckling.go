@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Линейный расчет на устойчивость
+//
+//	K·φ = λ·(-Kg)·φ
+//
+// K - симметричная положительно определенная матрица жесткости,
+// Kg - геометрическая матрица, в общем случае знаконеопределенная.
+// Искомые коэффициенты нагрузки λ наименьшие по модулю, поэтому
+// используется метод сдвига и обращения со сдвигом σ:
+//
+//	(K + σ·Kg)⁻¹·(-Kg)·φ = μ·φ, μ = 1/(λ - σ)
+//
+// Для σ между наибольшим отрицательным и наименьшим
+// положительным λ матрица K + σ·Kg положительно определена
+// и по разложению Холецкого K + σ·Kg = L·Lᵀ задача приводится
+// к стандартной симметричной:
+//
+//	C·y = μ·y, C = L⁻¹·(-Kg)·L⁻ᵀ, λ = σ + 1/μ, φ = L⁻ᵀ·y
+//
+// Наибольшие положительные μ соответствуют наименьшим λ > σ,
+// наименьшие отрицательные μ - наибольшим λ < σ. Пары находятся
+// неявным исчерпыванием оператора C по убыванию |μ|, поэтому
+// при σ около искомого λ сходимость быстрее, чем для σ = 0.
+// Отрицательные λ - потеря устойчивости при нагрузке обратного
+// знака. Для μ = 0 потери устойчивости нет.
+
+// результат расчета на устойчивость
+type buckling struct {
+	// коэффициенты нагрузки λ > 0 по возрастанию и формы
+	positive []eigen
+
+	// коэффициенты нагрузки λ < 0 по убыванию (обратная нагрузка)
+	negative []eigen
+}
+
+// оператор C = L⁻¹·(-Kg)·L⁻ᵀ, K + σ·Kg = L·Lᵀ
+type bucklingOperator struct {
+	L  [][]float64
+	Kg [][]float64
+	t  []float64
+}
+
+func (b bucklingOperator) size() int {
+	return len(b.L)
+}
+
+func (b bucklingOperator) mul(y, x []float64) {
+	dense(b.Kg).mul(b.t, upperSolve(b.L, x))
+	for i := range b.t {
+		b.t[i] = -b.t[i]
+	}
+	copy(y, lowerSolve(b.L, b.t))
+}
+
+// Расчет nev наименьших положительных коэффициентов нагрузки
+// и nev наименьших по модулю отрицательных коэффициентов
+// со сдвигом σ. Сдвиг - оценка искомого коэффициента, он должен
+// быть меньше наименьшего положительного и больше наибольшего
+// отрицательного коэффициента, при σ = 0 сдвига нет.
+func bucklingAnalysis(K, Kg [][]float64, σ float64, nev int) (b buckling, err error) {
+	if err = checkInput(K); err != nil {
+		return
+	}
+	if len(Kg) != len(K) {
+		err = fmt.Errorf("size of geometric matrix %d is not same as stiffness %d",
+			len(Kg), len(K))
+		return
+	}
+	if err = checkSymmetric(K); err != nil {
+		return
+	}
+	if err = checkSymmetric(Kg); err != nil {
+		return
+	}
+	n := len(K)
+	if nev <= 0 || nev > n {
+		nev = n
+	}
+
+	if _, err = cholesky(K); err != nil {
+		err = fmt.Errorf("stiffness matrix: %v", err)
+		return
+	}
+
+	// K + σ·Kg
+	Kσ := make([][]float64, n)
+	for i := range Kσ {
+		Kσ[i] = make([]float64, n)
+		for j := range Kσ[i] {
+			Kσ[i][j] = K[i][j] + σ*Kg[i][j]
+		}
+	}
+	L, err := cholesky(Kσ)
+	if err != nil {
+		err = fmt.Errorf("shift σ = %.5e is not between negative and positive load factors: %v",
+			σ, err)
+		return
+	}
+	op := bucklingOperator{L: L, Kg: Kg, t: make([]float64, n)}
+
+	// По закону инерции Сильвестра C и -Kg имеют одинаковое
+	// количество положительных и отрицательных собственных
+	// значений, поэтому количество искомых пар известно до
+	// итераций и нулевые μ (Kg вырождена) не находятся.
+	eg, err := jacobi(Kg, cyclicSweep)
+	if err != nil {
+		err = fmt.Errorf("geometric matrix: %v", err)
+		return
+	}
+	tol := math.Sqrt(𝛆) * normInf(Kg)
+	if tol == 0.0 {
+		err = fmt.Errorf("all elements of geometric matrix is zeros")
+		return
+	}
+	var positive, negative int
+	for i := range eg {
+		if eg[i].𝜦 < -tol {
+			positive++
+		}
+		if eg[i].𝜦 > tol {
+			negative++
+		}
+	}
+	if positive+negative == 0 {
+		return
+	}
+	wantPositive, wantNegative := positive, negative
+	if wantPositive > nev {
+		wantPositive = nev
+	}
+	if wantNegative > nev {
+		wantNegative = nev
+	}
+
+	// Пары находятся по убыванию |μ|, то есть по возрастанию
+	// |λ - σ|, пока не найдены искомые пары обоих знаков.
+	// Значения μ разных знаков могут быть равны по модулю,
+	// такая пара разделяется в power.
+	var np, nn int
+	e, err := exhOperatorUntil(op, positive+negative, func(e eigen) bool {
+		if np >= wantPositive && nn >= wantNegative {
+			return true
+		}
+		if e.𝜦 > 0 {
+			np++
+		} else {
+			nn++
+		}
+		return false
+	})
+	if err != nil {
+		return
+	}
+	for i := range e {
+		φ := upperSolve(L, e[i].𝑿)
+		if _, err = oneMax(φ, φ); err != nil {
+			return
+		}
+		pair := eigen{𝑿: φ, 𝜦: σ + 1.0/e[i].𝜦, stop: e[i].stop}
+		if e[i].𝜦 > 0 {
+			b.positive = append(b.positive, pair)
+		} else {
+			b.negative = append(b.negative, pair)
+		}
+	}
+
+	sort.SliceStable(b.positive, func(i, j int) bool {
+		return b.positive[i].𝜦 < b.positive[j].𝜦
+	})
+	sort.SliceStable(b.negative, func(i, j int) bool {
+		return b.negative[i].𝜦 > b.negative[j].𝜦
+	})
+	if len(b.positive) > nev {
+		b.positive = b.positive[:nev]
+	}
+	if len(b.negative) > nev {
+		b.negative = b.negative[:nev]
+	}
+	if output {
+		for i := range b.positive {
+			fmt.Printf("buckling %d: λ = %.14e\n", i, b.positive[i].𝜦)
+		}
+		for i := range b.negative {
+			fmt.Printf("buckling (load reversal) %d: λ = %.14e\n", i, b.negative[i].𝜦)
+		}
+	}
+	return
+}
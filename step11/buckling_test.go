@@ -0,0 +1,131 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBuckling(t *testing.T) {
+	defer harmonicStart()()
+
+	// знаконеопределенная Kg:
+	// det(K - λ·diag(1,-1)) = 3 - λ² = 0
+	K := [][]float64{
+		{2, -1},
+		{-1, 2},
+	}
+	Kg := [][]float64{
+		{-1, 0},
+		{0, 1},
+	}
+	b, err := bucklingAnalysis(K, Kg, 0.0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.positive) != 1 || math.Abs(b.positive[0].𝜦-math.Sqrt(3)) > 1e-12 {
+		t.Errorf("positive: %v", b.positive)
+	}
+	if len(b.negative) != 1 || math.Abs(b.negative[0].𝜦+math.Sqrt(3)) > 1e-12 {
+		t.Errorf("negative: %v", b.negative)
+	}
+	checkBuckling(t, K, Kg, b)
+
+	t.Run("column", func(t *testing.T) {
+		// стержень со сжимающей силой только в части узлов:
+		// Kg вырождена
+		n := 8
+		K := make([][]float64, n)
+		Kg := make([][]float64, n)
+		for i := range K {
+			K[i] = make([]float64, n)
+			Kg[i] = make([]float64, n)
+			K[i][i] = 2
+			if i > 0 {
+				K[i][i-1], K[i-1][i] = -1, -1
+			}
+		}
+		for i := 0; i < n/2; i++ {
+			Kg[i][i] = -0.1 * float64(i+1)
+		}
+		b, err := bucklingAnalysis(K, Kg, 0.0, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(b.positive) != 2 || len(b.negative) != 0 {
+			t.Fatalf("positive %d, negative %d", len(b.positive), len(b.negative))
+		}
+		if b.positive[0].𝜦 > b.positive[1].𝜦 {
+			t.Errorf("load factors is not increasing")
+		}
+		checkBuckling(t, K, Kg, b)
+
+		// сдвиг около наименьшего коэффициента
+		bs, err := bucklingAnalysis(K, Kg, 0.9*b.positive[0].𝜦, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(bs.positive) != 2 || len(bs.negative) != 0 {
+			t.Fatalf("shift: positive %d, negative %d", len(bs.positive), len(bs.negative))
+		}
+		for i := range bs.positive {
+			if math.Abs(bs.positive[i].𝜦-b.positive[i].𝜦) > 1e-10*b.positive[i].𝜦 {
+				t.Errorf("shift: %.14e != %.14e", bs.positive[i].𝜦, b.positive[i].𝜦)
+			}
+		}
+		checkBuckling(t, K, Kg, bs)
+	})
+
+	t.Run("shift", func(t *testing.T) {
+		// λ = ±√3
+		for _, σ := range []float64{1.5, -1.0} {
+			b, err := bucklingAnalysis(K, Kg, σ, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(b.positive) != 1 || math.Abs(b.positive[0].𝜦-math.Sqrt(3)) > 1e-12 {
+				t.Errorf("σ = %v: positive: %v", σ, b.positive)
+			}
+			if len(b.negative) != 1 || math.Abs(b.negative[0].𝜦+math.Sqrt(3)) > 1e-12 {
+				t.Errorf("σ = %v: negative: %v", σ, b.negative)
+			}
+			checkBuckling(t, K, Kg, b)
+		}
+
+		// K + σ·Kg не является положительно определенной
+		if _, err := bucklingAnalysis(K, Kg, 2.0, 1); err == nil {
+			t.Errorf("shift outside of load factors: error is not found")
+		} else {
+			t.Log(err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct{ K, Kg [][]float64 }{
+			{K: [][]float64{{1, 0}, {0, -1}}, Kg: Kg},
+			{K: K, Kg: [][]float64{{1}}},
+			{K: K, Kg: [][]float64{{1, 2}, {0, 1}}},
+		} {
+			if _, err := bucklingAnalysis(tc.K, tc.Kg, 0.0, 1); err == nil {
+				t.Errorf("error is not found: %v %v", tc.K, tc.Kg)
+			}
+		}
+	})
+}
+
+// K·φ + λ·Kg·φ = 0
+func checkBuckling(t *testing.T, K, Kg [][]float64, b buckling) {
+	t.Helper()
+	for _, e := range append(append([]eigen{}, b.positive...), b.negative...) {
+		n := len(K)
+		Kx := make([]float64, n)
+		Kgx := make([]float64, n)
+		dense(K).mul(Kx, e.𝑿)
+		dense(Kg).mul(Kgx, e.𝑿)
+		for i := range Kx {
+			if r := math.Abs(Kx[i] + e.𝜦*Kgx[i]); r > 1e-10 {
+				t.Errorf("λ = %v: residual %v", e.𝜦, r)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Степенной метод с ускорением многочленами Чебышева
+//
+// Скорость сходимости степенного метода определяется отношением
+// |λ2|/|λ1|. Вместо x(k) = A·x(k-1) используется x(k) = Tm(A)·x(k-1),
+// где Tm - многочлен Чебышева степени m, минимальный по модулю
+// на интервале [a,b] нежелательных собственных значений.
+// Собственное значение вне интервала усиливается быстрее всех.
+//
+// Литература:
+// * Saad Y. Numerical methods for large eigenvalue problems. Chapter 7.4
+
+// степень многочлена Чебышева
+var chebyshevDegree int = 10
+
+// количество шагов Ланцоша для оценки интервала
+var lanczosSteps int = 20
+
+// Собственная пара вне интервала [a,b] нежелательных
+// собственных значений симметричного оператора
+func chebyshev(A operator, a, b float64) (e eigen, err error) {
+	if !(a < b) {
+		err = fmt.Errorf("interval is not valid: [%.5e, %.5e]", a, b)
+		return
+	}
+	n := A.size()
+	c := (a + b) / 2.0
+	d := (b - a) / 2.0
+
+	// любое значение вне интервала для масштабирования
+	// многочлена, Tm(γ) = 1
+	γ := b + (b - a)
+	σ1 := d / (γ - c)
+
+	// y = (A - c·I)·x
+	Ac := func(y, x []float64) {
+		A.mul(y, x)
+		for i := range y {
+			y[i] -= c * x[i]
+		}
+	}
+
+	yLast := make([]float64, n)
+	y := make([]float64, n)
+	t := make([]float64, n)
+	poly := func(z, x []float64) {
+		// y(0) = x
+		// y(1) = σ1/d · (A - c·I) · x
+		copy(yLast, x)
+		Ac(y, x)
+		for i := range y {
+			y[i] *= σ1 / d
+		}
+		// y(k+1) = 2·σ(k+1)/d · (A - c·I) · y(k) - σ(k)·σ(k+1) · y(k-1)
+		σ := σ1
+		for k := 1; k < chebyshevDegree; k++ {
+			σNew := 1.0 / (2.0/σ1 - σ)
+			Ac(t, y)
+			for i := range t {
+				t[i] = 2.0*σNew/d*t[i] - σ*σNew*yLast[i]
+			}
+			yLast, y, t = y, t, yLast
+			σ = σNew
+		}
+		copy(z, y)
+	}
+
+	x := make([]float64, n)
+	if err = startFor(mulFunc{n: n, f: poly}, x); err != nil {
+		return
+	}
+	var stop string
+	if _, stop, err = power(poly, x); err != nil {
+		return
+	}
+
+	e = eigen{𝑿: x, 𝜦: rayleigh(A, x), stop: stop}
+	return
+}
+
+// способ оценки интервала нежелательных собственных значений
+type intervalMethod int
+
+const (
+	// значения Ритца метода Ланцоша
+	lanczosInterval intervalMethod = iota
+
+	// компоненты кругов Гершгорина
+	gershgorinInterval
+)
+
+// Оценка интервала нежелательных собственных значений
+// симметричного оператора.
+// Для largest искомое значение наибольшее, иначе наименьшее.
+func chebyshevInterval(A operator, largest bool, method intervalMethod) (a, b float64, err error) {
+	switch method {
+	case lanczosInterval:
+		return lanczosBounds(A, largest)
+	case gershgorinInterval:
+		return discInterval(A, largest)
+	}
+	err = fmt.Errorf("interval method %d is not supported", method)
+	return
+}
+
+// Интервал по значениям Ритца метода Ланцоша
+func lanczosBounds(A operator, largest bool) (a, b float64, err error) {
+	ritz, β, err := lanczos(A, lanczosSteps)
+	if err != nil {
+		return
+	}
+	if len(ritz) < 2 {
+		err = fmt.Errorf("not enough Ritz values: %d", len(ritz))
+		return
+	}
+	sort.Float64s(ritz)
+
+	// значения Ритца лежат внутри спектра, поэтому внешние
+	// границы расширяются на величину последнего β
+	if largest {
+		a = ritz[0] - β
+		b = ritz[len(ritz)-2]
+		return
+	}
+	a = ritz[1]
+	b = ritz[len(ritz)-1] + β
+	return
+}
+
+// Интервал по кругам Гершгорина. Оценка надежна, но требует,
+// чтобы крайний круг не пересекался с остальными: тогда в нем
+// ровно одно искомое собственное значение, а остальные
+// значения лежат в других компонентах.
+func discInterval(A operator, largest bool) (a, b float64, err error) {
+	bs, err := spectralBounds(A)
+	if err != nil {
+		return
+	}
+	cs := bs.rows.components
+	if len(cs) < 2 {
+		err = fmt.Errorf("wanted eigenvalue is not isolated by Gershgorin discs")
+		return
+	}
+	if largest {
+		if cs[len(cs)-1].count != 1 {
+			err = fmt.Errorf("wanted eigenvalue is not isolated by Gershgorin discs")
+			return
+		}
+		a = cs[0].lower
+		b = cs[len(cs)-2].upper
+		return
+	}
+	if cs[0].count != 1 {
+		err = fmt.Errorf("wanted eigenvalue is not isolated by Gershgorin discs")
+		return
+	}
+	a = cs[1].lower
+	b = cs[len(cs)-1].upper
+	return
+}
+
+// Метод Ланцоша с полной переортогонализацией.
+// Возвращает значения Ритца и последний внедиагональный элемент β.
+func lanczos(A operator, k int) (ritz []float64, β float64, err error) {
+	n := A.size()
+	if k > n {
+		k = n
+	}
+
+	q := make([]float64, n)
+	initialize(q)
+	normalize(q)
+
+	var (
+		Q     [][]float64
+		alpha []float64
+		beta  []float64
+	)
+	w := make([]float64, n)
+	for j := 0; j < k; j++ {
+		Q = append(Q, q)
+		A.mul(w, q)
+
+		var α float64
+		for i := range w {
+			α += w[i] * q[i]
+		}
+		alpha = append(alpha, α)
+
+		// полная переортогонализация
+		for _, v := range Q {
+			var vw float64
+			for i := range w {
+				vw += v[i] * w[i]
+			}
+			for i := range w {
+				w[i] -= vw * v[i]
+			}
+		}
+
+		β = 0.0
+		for i := range w {
+			β += w[i] * w[i]
+		}
+		β = math.Sqrt(β)
+		if β <= 𝛆*math.Abs(α) || j == k-1 {
+			break
+		}
+		beta = append(beta, β)
+
+		q = make([]float64, n)
+		for i := range w {
+			q[i] = w[i] / β
+		}
+	}
+
+	// трехдиагональная матрица T = Qᵀ·A·Q
+	m := len(alpha)
+	T := make([][]float64, m)
+	for i := range T {
+		T[i] = make([]float64, m)
+		T[i][i] = alpha[i]
+		if i > 0 {
+			T[i][i-1] = beta[i-1]
+			T[i-1][i] = beta[i-1]
+		}
+	}
+	es, err := jacobi(T, cyclicSweep)
+	if err != nil {
+		return
+	}
+	for i := range es {
+		ritz = append(ritz, es[i].𝜦)
+	}
+	return
+}
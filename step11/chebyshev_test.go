@@ -0,0 +1,151 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// оператор с подсчетом количества умножений на вектор
+type counter struct {
+	operator
+	amount int
+}
+
+func (c *counter) mul(y, x []float64) {
+	c.amount++
+	c.operator.mul(y, x)
+}
+
+// симметричная матрица H·Λ·H с заданными собственными значениями
+func spectrum(values []float64) (A [][]float64) {
+	n := len(values)
+	D := make([][]float64, n)
+	w := make([]float64, n)
+	for i := range D {
+		D[i] = make([]float64, n)
+		D[i][i] = values[i]
+		w[i] = 1.0 + math.Sin(float64(i))
+	}
+	return householderSimilarity(D, w)
+}
+
+func TestChebyshev(t *testing.T) {
+	defer harmonicStart()()
+
+	// кластер собственных значений около наибольшего:
+	// |λ2|/|λ1| = 0.95
+	values := []float64{1.0, 0.95}
+	for i := 0; i < 18; i++ {
+		values = append(values, 0.9-0.05*float64(i))
+	}
+	A := spectrum(values)
+
+	// степенной метод
+	pmCounter := &counter{operator: dense(A)}
+	x := make([]float64, len(A))
+	initialize(x)
+	if _, _, err := power(pmCounter.mul, x); err != nil {
+		t.Fatal(err)
+	}
+	pmValue := rayleigh(dense(A), x)
+
+	t.Run("given interval", func(t *testing.T) {
+		chCounter := &counter{operator: dense(A)}
+		e, err := chebyshev(chCounter, -0.1, 0.95)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(e.𝜦-1.0) > 1e-12 {
+			t.Errorf("eigenvalue: %.14e", e.𝜦)
+		}
+		if math.Abs(pmValue-1.0) > 1e-12 {
+			t.Errorf("eigenvalue of power method: %.14e", pmValue)
+		}
+		t.Logf("matvec: power method = %d, chebyshev = %d",
+			pmCounter.amount, chCounter.amount)
+		if chCounter.amount*2 > pmCounter.amount {
+			t.Errorf("not enough acceleration: %d > %d/2",
+				chCounter.amount, pmCounter.amount)
+		}
+	})
+
+	t.Run("Lanczos interval", func(t *testing.T) {
+		for _, largest := range []bool{true, false} {
+			a, b, err := chebyshevInterval(dense(A), largest, lanczosInterval)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("largest = %v. interval [%.5f, %.5f]", largest, a, b)
+
+			e, err := chebyshev(dense(A), a, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect := values[len(values)-1]
+			if largest {
+				expect = values[0]
+			}
+			if math.Abs(e.𝜦-expect) > 1e-12 {
+				t.Errorf("eigenvalue: %.14e != %.14e", e.𝜦, expect)
+			}
+		}
+	})
+
+	t.Run("Gershgorin interval", func(t *testing.T) {
+		// диагональное преобладание: круги не пересекаются
+		n := 8
+		D := zeros(n)
+		for i := range D {
+			D[i][i] = 1.0 + 0.5*float64(i)
+			if i > 0 {
+				D[i][i-1], D[i-1][i] = 0.05, 0.05
+			}
+		}
+		D[n-1][n-1] = 10.0
+		full, err := jacobi(D, cyclicSweep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lower, upper := full[0].𝜦, full[0].𝜦
+		for _, e := range full {
+			lower = math.Min(lower, e.𝜦)
+			upper = math.Max(upper, e.𝜦)
+		}
+		for _, largest := range []bool{true, false} {
+			a, b, err := chebyshevInterval(dense(D), largest, gershgorinInterval)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("largest = %v. interval [%.5f, %.5f]", largest, a, b)
+			expect := lower
+			if largest {
+				expect = upper
+			}
+			if a <= expect && expect <= b {
+				t.Fatalf("wanted eigenvalue %.5f is inside of interval", expect)
+			}
+
+			e, err := chebyshev(dense(D), a, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(e.𝜦-expect) > 1e-12 {
+				t.Errorf("eigenvalue: %.14e != %.14e", e.𝜦, expect)
+			}
+		}
+
+		// круги кластера пересекаются
+		if _, _, err := chebyshevInterval(dense(A), true, gershgorinInterval); err == nil {
+			t.Errorf("error is not found")
+		}
+	})
+
+	t.Run("not valid interval", func(t *testing.T) {
+		if _, err := chebyshev(dense(A), 1, 1); err == nil {
+			t.Errorf("error is not found")
+		}
+		if _, _, err := chebyshevInterval(dense(A), true, intervalMethod(5)); err == nil {
+			t.Errorf("method: error is not found")
+		}
+	})
+}
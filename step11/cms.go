@@ -0,0 +1,309 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Синтез форм подконструкций по методу Крейга-Бэмптона
+//
+// Степени свободы подконструкции делятся на граничные b
+// и внутренние i. Перемещения подконструкции выражаются через
+// граничные перемещения и модальные координаты q:
+//
+//	[xb]   [ I   0 ] [xb]
+//	[xi] = [ Φc  Φn] [q ]
+//
+//	Φc = -Kii⁻¹·Kib - формы статических ограничений
+//	Φn - формы колебаний с закрепленной границей Kii·φ = λ·Mii·φ
+//
+// Подконструкции соединяются по общим граничным степеням
+// свободы интерфейса, собранная задача решается в координатах
+// [xb интерфейса; q всех подконструкций].
+
+// подконструкция
+type substructure struct {
+	K, M [][]float64
+
+	// местные номера граничных степеней свободы
+	boundary []int
+
+	// номера степеней свободы интерфейса сборки для boundary
+	interfaceDOF []int
+
+	// количество форм с закрепленной границей,
+	// при modes = 0 учитываются все формы
+	modes int
+}
+
+// результат синтеза форм
+type cms struct {
+	// собственные пары собранной задачи в координатах
+	// [xb интерфейса; q подконструкций]
+	e []eigen
+
+	// формы подконструкций в местных степенях свободы:
+	// shapes[форма][подконструкция]
+	shapes [][][]float64
+}
+
+// Матрица преобразования подконструкции x = T·[xb; q]
+func (c substructure) transform() (T [][]float64, err error) {
+	n := len(c.K)
+	if len(c.M) != n {
+		err = fmt.Errorf("size of mass matrix %d is not same as stiffness %d", len(c.M), n)
+		return
+	}
+	if c.modes < 0 {
+		err = fmt.Errorf("amount of modes %d is negative", c.modes)
+		return
+	}
+	if len(c.boundary) != len(c.interfaceDOF) {
+		err = fmt.Errorf("amount of boundary dofs %d is not same as interface dofs %d",
+			len(c.boundary), len(c.interfaceDOF))
+		return
+	}
+	isBoundary := make([]bool, n)
+	for _, b := range c.boundary {
+		if b < 0 || n <= b || isBoundary[b] {
+			err = fmt.Errorf("boundary dof %d is not valid", b)
+			return
+		}
+		isBoundary[b] = true
+	}
+	var inner []int
+	for i := 0; i < n; i++ {
+		if !isBoundary[i] {
+			inner = append(inner, i)
+		}
+	}
+	nb := len(c.boundary)
+
+	sub := func(A [][]float64, rows, cols []int) (B [][]float64) {
+		B = make([][]float64, len(rows))
+		for a := range rows {
+			B[a] = make([]float64, len(cols))
+			for b := range cols {
+				B[a][b] = A[rows[a]][cols[b]]
+			}
+		}
+		return
+	}
+
+	// формы с закрепленной границей
+	var normal []eigen
+	if len(inner) > 0 {
+		if normal, err = generalized(sub(c.K, inner, inner), sub(c.M, inner, inner)); err != nil {
+			err = fmt.Errorf("fixed-interface modes: %v", err)
+			return
+		}
+	}
+	if 0 < c.modes && c.modes < len(normal) {
+		normal = normal[:c.modes]
+	}
+
+	T = make([][]float64, n)
+	for i := range T {
+		T[i] = make([]float64, nb+len(normal))
+	}
+	for j, b := range c.boundary {
+		T[b][j] = 1.0
+	}
+	if len(inner) == 0 {
+		return
+	}
+
+	// формы статических ограничений
+	f, err := luFactorize(sub(c.K, inner, inner), 0.0)
+	if err != nil {
+		err = fmt.Errorf("stiffness of inner dofs: %v", err)
+		return
+	}
+	Kib := sub(c.K, inner, c.boundary)
+	rhs := make([]float64, len(inner))
+	for j := range c.boundary {
+		for a := range inner {
+			rhs[a] = -Kib[a][j]
+		}
+		x := f.solve(rhs)
+		for a, i := range inner {
+			T[i][j] = x[a]
+		}
+	}
+	for k := range normal {
+		for a, i := range inner {
+			T[i][nb+k] = normal[k].𝑿[a]
+		}
+	}
+	return
+}
+
+// Сборка подконструкций и решение собранной задачи
+func craigBampton(components []substructure) (res cms, err error) {
+	if len(components) == 0 {
+		err = fmt.Errorf("components are not found")
+		return
+	}
+
+	// степени свободы интерфейса
+	ni := 0
+	for c := range components {
+		seen := map[int]bool{}
+		for _, d := range components[c].interfaceDOF {
+			if d < 0 {
+				err = fmt.Errorf("substructure %d: interface dof %d is negative", c, d)
+				return
+			}
+			if seen[d] {
+				err = fmt.Errorf("substructure %d: interface dof %d is repeated", c, d)
+				return
+			}
+			seen[d] = true
+			if d+1 > ni {
+				ni = d + 1
+			}
+		}
+	}
+	used := make([]int, ni)
+	for _, c := range components {
+		for _, d := range c.interfaceDOF {
+			used[d]++
+		}
+	}
+	for d := range used {
+		if used[d] == 0 {
+			err = fmt.Errorf("interface dof %d is not connected", d)
+			return
+		}
+	}
+
+	// преобразование и номера координат подконструкций
+	Ts := make([][][]float64, len(components))
+	index := make([][]int, len(components))
+	size := ni
+	for c, comp := range components {
+		if Ts[c], err = comp.transform(); err != nil {
+			err = fmt.Errorf("substructure %d: %v", c, err)
+			return
+		}
+		index[c] = append(index[c], comp.interfaceDOF...)
+		for k := len(comp.boundary); k < len(Ts[c][0]); k++ {
+			index[c] = append(index[c], size)
+			size++
+		}
+	}
+
+	K := zeros(size)
+	M := zeros(size)
+	for c, comp := range components {
+		Kc := transformed(comp.K, Ts[c])
+		Mc := transformed(comp.M, Ts[c])
+		for a := range index[c] {
+			for b := range index[c] {
+				K[index[c][a]][index[c][b]] += Kc[a][b]
+				M[index[c][a]][index[c][b]] += Mc[a][b]
+			}
+		}
+	}
+	if output {
+		fmt.Printf("craig-bampton: interface %d, reduced size %d\n", ni, size)
+	}
+
+	if res.e, err = generalized(K, M); err != nil {
+		return
+	}
+
+	// формы подконструкций
+	for _, e := range res.e {
+		var shapes [][]float64
+		for c := range components {
+			q := make([]float64, len(index[c]))
+			for a := range q {
+				q[a] = e.𝑿[index[c][a]]
+			}
+			shapes = append(shapes, expandWith(Ts[c], q))
+		}
+		res.shapes = append(res.shapes, shapes)
+	}
+	return
+}
+
+// Проверка совместности по уравнениям внутренних степеней
+// свободы каждой подконструкции:
+//
+//	(Kii - λ·Mii)·xi + (Kib - λ·Mib)·xb = 0
+//
+// Перемещения интерфейса xb восстанавливаются по xi методом
+// наименьших квадратов и сравниваются с перемещениями собранной
+// задачи. Возвращается наибольшая разность, отнесенная
+// к наибольшему перемещению формы. Для всех форм с закрепленной
+// границей разность на уровне ошибок округления, при усечении
+// форм - оценка погрешности подконструкции.
+func (res cms) compatibility(components []substructure) (diff float64) {
+	for m, shapes := range res.shapes {
+		λ := res.e[m].𝜦
+		var scale float64
+		for c := range shapes {
+			for _, v := range shapes[c] {
+				scale = math.Max(scale, math.Abs(v))
+			}
+		}
+		if scale == 0.0 {
+			continue
+		}
+		for c, comp := range components {
+			x := shapes[c]
+			isBoundary := make([]bool, len(x))
+			for _, b := range comp.boundary {
+				isBoundary[b] = true
+			}
+			nb := len(comp.boundary)
+
+			// B·xb = r, B = Kib - λ·Mib, r = -(Kii - λ·Mii)·xi
+			var B [][]float64
+			var r []float64
+			for i := range x {
+				if isBoundary[i] {
+					continue
+				}
+				row := make([]float64, nb)
+				for j, b := range comp.boundary {
+					row[j] = comp.K[i][b] - λ*comp.M[i][b]
+				}
+				var ri float64
+				for k := range x {
+					if !isBoundary[k] {
+						ri -= (comp.K[i][k] - λ*comp.M[i][k]) * x[k]
+					}
+				}
+				B = append(B, row)
+				r = append(r, ri)
+			}
+			if len(B) < nb {
+				continue
+			}
+
+			// Bᵀ·B·xb = Bᵀ·r
+			BB := zeros(nb)
+			Br := make([]float64, nb)
+			for a := range B {
+				for j := 0; j < nb; j++ {
+					Br[j] += B[a][j] * r[a]
+					for k := 0; k < nb; k++ {
+						BB[j][k] += B[a][j] * B[a][k]
+					}
+				}
+			}
+			f, err := luFactorize(BB, 0.0)
+			if err != nil {
+				// внутренние степени свободы не связаны с границей
+				continue
+			}
+			xb := f.solve(Br)
+			for j, b := range comp.boundary {
+				diff = math.Max(diff, math.Abs(xb[j]-x[b])/scale)
+			}
+		}
+	}
+	return
+}
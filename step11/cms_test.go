@@ -0,0 +1,102 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// цепочка пружин k и масс m: земля - 1 - 2 - ... - n
+func chain(n int, k, m float64, ground bool) (K, M [][]float64) {
+	K, M = zeros(n), zeros(n)
+	for i := 0; i < n; i++ {
+		M[i][i] = m
+		if i > 0 {
+			K[i-1][i-1] += k
+			K[i][i] += k
+			K[i-1][i] -= k
+			K[i][i-1] -= k
+		}
+	}
+	if ground {
+		K[0][0] += k
+	}
+	return
+}
+
+func TestCraigBampton(t *testing.T) {
+	k, m := 1000.0, 2.0
+	K, M := chain(10, k, m, true)
+	full, err := generalized(K, M)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// подконструкции: узлы 0..4 и 4..9, общий узел 4
+	// масса общего узла в первой подконструкции
+	KA, MA := chain(5, k, m, true)
+	KB, MB := chain(6, k, m, false)
+	MB[0][0] = 0
+
+	for _, tc := range []struct {
+		modes int
+		tol   float64
+	}{
+		{modes: 0, tol: 1e-12},
+		{modes: 2, tol: 1e-2},
+	} {
+		components := []substructure{
+			{K: KA, M: MA, boundary: []int{4}, interfaceDOF: []int{0}, modes: tc.modes},
+			{K: KB, M: MB, boundary: []int{0}, interfaceDOF: []int{0}, modes: tc.modes},
+		}
+		res, err := craigBampton(components)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.modes == 0 && len(res.e) != len(full) {
+			t.Fatalf("amount of modes: %d", len(res.e))
+		}
+		if tc.modes == 2 && len(res.e) != 5 {
+			t.Fatalf("reduced size: %d", len(res.e))
+		}
+		// при усечении форм уравнения подконструкций
+		// выполняются приближенно
+		d := res.compatibility(components)
+		t.Logf("modes %2d: compatibility %.3e", tc.modes, d)
+		if tc.modes == 0 && d > 1e-8 {
+			t.Errorf("interface is not compatible: %v", d)
+		}
+		if tc.modes == 2 && d < 1e-3 {
+			t.Errorf("truncation error is not found: %v", d)
+		}
+
+		// форма полной модели по формам подконструкций
+		x := append(append([]float64{}, res.shapes[0][0]...), res.shapes[0][1][1:]...)
+		if v := mac(x, full[0].𝑿); math.Abs(v-1) > tc.tol {
+			t.Errorf("modes %d: MAC = %v", tc.modes, v)
+		}
+		for i := 0; i < 2; i++ {
+			e := math.Abs(res.e[i].𝜦-full[i].𝜦) / full[i].𝜦
+			t.Logf("modes %2d: mode %d error %.3e", tc.modes, i, e)
+			if e > tc.tol || res.e[i].𝜦 < full[i].𝜦*(1-1e-12) {
+				t.Errorf("mode %d: %.14e != %.14e", i, res.e[i].𝜦, full[i].𝜦)
+			}
+		}
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, cs := range [][]substructure{
+			{},
+			{{K: KA, M: MA, boundary: []int{4}, interfaceDOF: []int{1}}},
+			{{K: KA, M: MA, boundary: []int{4}, interfaceDOF: []int{-1}}},
+			{{K: KA, M: MA, boundary: []int{4}}},
+			{{K: KA, M: MA, boundary: []int{5}, interfaceDOF: []int{0}}},
+			{{K: KA, M: MA, boundary: []int{3, 4}, interfaceDOF: []int{0, 0}}},
+			{{K: KA, M: MB, boundary: []int{4}, interfaceDOF: []int{0}}},
+			{{K: KA, M: MA, boundary: []int{4}, interfaceDOF: []int{0}, modes: -1}},
+		} {
+			if _, err := craigBampton(cs); err == nil {
+				t.Errorf("error is not found: %v", cs)
+			}
+		}
+	})
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Граничные условия и многоточечные связи
+//
+// Перемещения всех степеней свободы x выражаются через
+// независимые степени свободы q:
+//
+//	x = T·q
+//
+// Закрепленной степени свободы соответствует нулевая строка T,
+// зависимой степени свободы со связью x[s] = Σ c·x[m] - строка
+// с коэффициентами c в столбцах независимых m.
+// Преобразованные матрицы:
+//
+//	Kq = Tᵀ·K·T, Mq = Tᵀ·M·T
+
+// степень свободы узла
+type dof struct {
+	node, dir int
+}
+
+// номера степеней свободы в глобальном векторе
+type dofMap map[dof]int
+
+// линейная связь x[slave] = Σ c[i]·x[masters[i]]
+type mpc struct {
+	slave   dof
+	masters []dof
+	c       []float64
+}
+
+// ограничения модели
+type constraints struct {
+	dofs  dofMap
+	fixed []dof
+	links []mpc
+}
+
+// Матрица преобразования T размером n x m
+func (cs constraints) transform() (T [][]float64, err error) {
+	n := len(cs.dofs)
+	index := func(d dof) (i int, err error) {
+		i, ok := cs.dofs[d]
+		if !ok || i < 0 || n <= i {
+			err = fmt.Errorf("degree of freedom %v is not found", d)
+		}
+		return
+	}
+
+	const (
+		independent = iota
+		fixed
+		slave
+	)
+	kind := make([]int, n)
+	for _, d := range cs.fixed {
+		var i int
+		if i, err = index(d); err != nil {
+			return
+		}
+		kind[i] = fixed
+	}
+	for _, l := range cs.links {
+		var i int
+		if i, err = index(l.slave); err != nil {
+			return
+		}
+		if len(l.masters) != len(l.c) {
+			err = fmt.Errorf("constraint of %v: amount of masters %d is not same as coefficients %d",
+				l.slave, len(l.masters), len(l.c))
+			return
+		}
+		switch kind[i] {
+		case fixed:
+			err = fmt.Errorf("slave %v is fixed", l.slave)
+			return
+		case slave:
+			err = fmt.Errorf("slave %v has several constraints", l.slave)
+			return
+		}
+		kind[i] = slave
+	}
+
+	// столбцы независимых степеней свободы
+	column := make([]int, n)
+	m := 0
+	for i := range kind {
+		column[i] = -1
+		if kind[i] == independent {
+			column[i] = m
+			m++
+		}
+	}
+	if m == 0 {
+		err = fmt.Errorf("all degrees of freedom are fixed")
+		return
+	}
+
+	T = make([][]float64, n)
+	for i := range T {
+		T[i] = make([]float64, m)
+		if kind[i] == independent {
+			T[i][column[i]] = 1.0
+		}
+	}
+	for _, l := range cs.links {
+		s, _ := index(l.slave)
+		for k, d := range l.masters {
+			var i int
+			if i, err = index(d); err != nil {
+				return
+			}
+			switch kind[i] {
+			case slave:
+				err = fmt.Errorf("master %v of %v is slave", d, l.slave)
+				return
+			case fixed:
+				// закрепленная степень свободы не перемещается
+				continue
+			}
+			T[s][column[i]] += l.c[k]
+		}
+	}
+	return
+}
+
+// Tᵀ·A·T для симметричной A
+func transformed(A, T [][]float64) (B [][]float64) {
+	n := len(T)
+	m := len(T[0])
+	AT := make([][]float64, n)
+	for i := range AT {
+		AT[i] = make([]float64, m)
+		for k := 0; k < n; k++ {
+			if A[i][k] == 0.0 {
+				continue
+			}
+			for j := 0; j < m; j++ {
+				AT[i][j] += A[i][k] * T[k][j]
+			}
+		}
+	}
+	B = zeros(m)
+	for k := 0; k < n; k++ {
+		for i := 0; i < m; i++ {
+			if T[k][i] == 0.0 {
+				continue
+			}
+			for j := 0; j < m; j++ {
+				B[i][j] += T[k][i] * AT[k][j]
+			}
+		}
+	}
+	// симметризация ошибок округления
+	for i := 0; i < m; i++ {
+		for j := i + 1; j < m; j++ {
+			B[i][j] = (B[i][j] + B[j][i]) / 2.0
+			B[j][i] = B[i][j]
+		}
+	}
+	return
+}
+
+// x = T·q
+func expandWith(T [][]float64, q []float64) (x []float64) {
+	x = make([]float64, len(T))
+	for i := range T {
+		for j := range q {
+			x[i] += T[i][j] * q[j]
+		}
+	}
+	return
+}
+
+// Собственные пары K·x = λ·M·x с ограничениями.
+// Вектора содержат все степени свободы.
+func (cs constraints) solve(K, M [][]float64) (e []eigen, err error) {
+	if len(K) != len(cs.dofs) || len(M) != len(cs.dofs) {
+		err = fmt.Errorf("size of matrices %d, %d is not same as amount of dofs %d",
+			len(K), len(M), len(cs.dofs))
+		return
+	}
+	T, err := cs.transform()
+	if err != nil {
+		return
+	}
+	if e, err = generalized(transformed(K, T), transformed(M, T)); err != nil {
+		return
+	}
+	for i := range e {
+		e[i].𝑿 = expandWith(T, e[i].𝑿)
+	}
+	return
+}
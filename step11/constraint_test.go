@@ -0,0 +1,185 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConstraints(t *testing.T) {
+	k, mass := 1000.0, 2.0
+
+	t.Run("rigid link of masses", func(t *testing.T) {
+		// земля - k - m = m: две массы движутся вместе, λ = k/2m
+		m := model{
+			kind:     axialModel,
+			nodes:    []node{{x: 0}, {x: 1}, {x: 2}},
+			elements: []element{{kind: springElement, n1: 0, n2: 1, k: k}},
+			supports: []support{{0, 0}},
+			masses:   []pointMass{{1, mass}, {2, mass}},
+		}
+		var err error
+		if m.links, err = m.rigidLink(1, 2); err != nil {
+			t.Fatal(err)
+		}
+		e, err := m.modes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(e) != 1 || math.Abs(e[0].𝜦-k/(2*mass)) > 1e-12 {
+			t.Fatalf("%v", e)
+		}
+		x := e[0].𝑿
+		if x[0] != 0 || x[1] != x[2] {
+			t.Errorf("eigenvector: %v", x)
+		}
+		// xᵀ·M·x = 1 для всех степеней свободы
+		if v := mass * (x[1]*x[1] + x[2]*x[2]); math.Abs(v-1) > 1e-12 {
+			t.Errorf("xᵀ·M·x = %v", v)
+		}
+	})
+
+	t.Run("linear constraint", func(t *testing.T) {
+		// x2 = 0.5·x1 + 0.5·x3 по пружинам без массы в узле 2
+		K := [][]float64{
+			{2 * k, -k, 0},
+			{-k, 2 * k, -k},
+			{0, -k, k},
+		}
+		M := [][]float64{
+			{mass, 0, 0},
+			{0, 0, 0},
+			{0, 0, mass},
+		}
+		cs := constraints{
+			dofs: dofMap{{0, 0}: 0, {1, 0}: 1, {2, 0}: 2},
+			links: []mpc{{
+				slave:   dof{1, 0},
+				masters: []dof{{0, 0}, {2, 0}},
+				c:       []float64{0.5, 0.5},
+			}},
+		}
+		e, err := cs.solve(K, M)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(e) != 2 {
+			t.Fatalf("amount of eigenpairs: %d", len(e))
+		}
+		for i := range e {
+			x := e[i].𝑿
+			if math.Abs(x[1]-0.5*(x[0]+x[2])) > 1e-14 {
+				t.Errorf("constraint is not satisfied: %v", x)
+			}
+			// Tᵀ·(K - λ·M)·x = 0 в направлениях (1, 0.5, 0) и (0, 0.5, 1)
+			r := make([]float64, 3)
+			for row := range K {
+				for col := range K {
+					r[row] += (K[row][col] - e[i].𝜦*M[row][col]) * x[col]
+				}
+			}
+			if math.Abs(r[0]+0.5*r[1]) > 1e-9 || math.Abs(r[2]+0.5*r[1]) > 1e-9 {
+				t.Errorf("residual: %v", r)
+			}
+		}
+	})
+
+	t.Run("frame", func(t *testing.T) {
+		// консоль с массой на жестком вылете
+		m := beamModel(10, 2.0, false, false)
+		last := len(m.nodes) - 1
+		m.nodes = append(m.nodes, node{x: 2.0, y: 0.5})
+		m.masses = []pointMass{{last + 1, 50}}
+		var err error
+		if m.links, err = m.rigidLink(last, last+1); err != nil {
+			t.Fatal(err)
+		}
+		e, err := m.modes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range e {
+			x := e[i].𝑿
+			u, v, θ := x[3*last], x[3*last+1], x[3*last+2]
+			us, vs, θs := x[3*last+3], x[3*last+4], x[3*last+5]
+			if math.Abs(us-(u-0.5*θ)) > 1e-12 || math.Abs(vs-v) > 1e-12 || θs != θ {
+				t.Errorf("mode %d: rigid link is not satisfied", i)
+			}
+		}
+	})
+
+	t.Run("symmetric transformation", func(t *testing.T) {
+		// Tᵀ·(A·T) без симметризации несимметрична на уровне ошибок
+		// округления, так как [i,j] и [j,i] суммируются в разном порядке
+		n, m := 9, 6
+		A := randomSymmetric(n, 3)
+		T := make([][]float64, n)
+		for i := range T {
+			T[i] = make([]float64, m)
+			for j := range T[i] {
+				T[i][j] = math.Sin(float64(7*i+3*j+1)) / 3.0
+			}
+		}
+		AT := make([][]float64, n)
+		for i := range AT {
+			AT[i] = make([]float64, m)
+			for k := 0; k < n; k++ {
+				for j := 0; j < m; j++ {
+					AT[i][j] += A[i][k] * T[k][j]
+				}
+			}
+		}
+		asymmetric := false
+		for i := 0; i < m; i++ {
+			for j := i + 1; j < m; j++ {
+				var bij, bji float64
+				for k := 0; k < n; k++ {
+					bij += T[k][i] * AT[k][j]
+					bji += T[k][j] * AT[k][i]
+				}
+				if bij != bji {
+					asymmetric = true
+				}
+			}
+		}
+		if !asymmetric {
+			t.Errorf("product is symmetric without symmetrization")
+		}
+
+		B := transformed(A, T)
+		for i := 0; i < m; i++ {
+			for j := i + 1; j < m; j++ {
+				if B[i][j] != B[j][i] {
+					t.Errorf("not symmetric in [%d,%d]: %.14e != %.14e", i, j, B[i][j], B[j][i])
+				}
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		dm := dofMap{{0, 0}: 0, {1, 0}: 1, {2, 0}: 2}
+		for _, cs := range []constraints{
+			{dofs: dm, fixed: []dof{{3, 0}}},
+			{dofs: dm, fixed: []dof{{0, 0}, {1, 0}, {2, 0}}},
+			{dofs: dm, fixed: []dof{{1, 0}}, links: []mpc{{slave: dof{1, 0}, masters: []dof{{0, 0}}, c: []float64{1}}}},
+			{dofs: dm, links: []mpc{
+				{slave: dof{1, 0}, masters: []dof{{0, 0}}, c: []float64{1}},
+				{slave: dof{1, 0}, masters: []dof{{2, 0}}, c: []float64{1}},
+			}},
+			{dofs: dm, links: []mpc{
+				{slave: dof{1, 0}, masters: []dof{{0, 0}}, c: []float64{1}},
+				{slave: dof{2, 0}, masters: []dof{{1, 0}}, c: []float64{1}},
+			}},
+			{dofs: dm, links: []mpc{{slave: dof{1, 0}, masters: []dof{{0, 0}}}}},
+			{dofs: dm, links: []mpc{{slave: dof{1, 0}, masters: []dof{{5, 0}}, c: []float64{1}}}},
+		} {
+			if _, err := cs.transform(); err == nil {
+				t.Errorf("error is not found: %v", cs)
+			} else {
+				t.Log(err)
+			}
+		}
+		if _, err := (model{kind: axialModel, nodes: []node{{}}}).rigidLink(0, 0); err == nil {
+			t.Errorf("rigid link: error is not found")
+		}
+	})
+}
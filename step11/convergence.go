@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Критерии сходимости
+//
+// Изменение нормирующего множителя max может быть малым,
+// когда вектор еще не сошелся (знакопеременная пара), а
+// изменение вектора - малым при медленной сходимости.
+// Поэтому критерий выбирается для каждого решения:
+//
+//	изменение λ:      |λ(k) - λ(k-1)| <= tol·|λ(k)|
+//	угол вектора:     sin ∠(x(k), x(k-1)) <= tol
+//	невязка:          ||A·x - λ·x|| / (||A||·||x||) <= tol
+//	комбинированный:  все или любой из критериев
+
+// Критерий сходимости решателей pm, exh, exhSelect, pmMulti
+// и chebyshev. При nil используется встроенный критерий решателя.
+var convergence criterion
+
+// состояние итерации для проверки сходимости
+type iteration struct {
+	A      operator
+	norm   float64 // оценка ||A||
+	x      []float64
+	xLast  []float64
+	l      float64
+	lLast  float64
+	number int64
+}
+
+// критерий сходимости
+type criterion interface {
+	// описание сработавшего критерия или пустая строка,
+	// если итерации не сошлись
+	check(it iteration) string
+}
+
+// изменение собственного значения
+type valueChange struct {
+	tol float64
+}
+
+func (c valueChange) check(it iteration) string {
+	d := math.Abs(it.l - it.lLast)
+	if d <= c.tol*math.Abs(it.l) {
+		return fmt.Sprintf("eigenvalue change %.5e", d/math.Abs(it.l))
+	}
+	return ""
+}
+
+// изменение угла вектора
+type angleChange struct {
+	tol float64
+}
+
+func (c angleChange) check(it iteration) string {
+	// sin = || x - (x,y)·y || для единичных x и y точнее,
+	// чем sqrt(1 - cos²) для малых углов
+	x := make([]float64, len(it.x))
+	y := make([]float64, len(it.x))
+	copy(x, it.x)
+	copy(y, it.xLast)
+	normalize(x)
+	normalize(y)
+	var xy float64
+	for i := range x {
+		xy += x[i] * y[i]
+	}
+	var sin float64
+	for i := range x {
+		d := x[i] - xy*y[i]
+		sin += d * d
+	}
+	sin = math.Sqrt(sin)
+	if sin <= c.tol {
+		return fmt.Sprintf("angle change %.5e", sin)
+	}
+	return ""
+}
+
+// невязка
+type residualChange struct {
+	tol float64
+}
+
+func (c residualChange) check(it iteration) string {
+	n := it.A.size()
+	y := make([]float64, n)
+	it.A.mul(y, it.x)
+	var r, xx float64
+	for i := range y {
+		d := y[i] - it.l*it.x[i]
+		r += d * d
+		xx += it.x[i] * it.x[i]
+	}
+	norm := math.Max(it.norm, math.Abs(it.l))
+	if norm == 0.0 {
+		return ""
+	}
+	r = math.Sqrt(r/xx) / norm
+	if r <= c.tol {
+		return fmt.Sprintf("residual %.5e", r)
+	}
+	return ""
+}
+
+// комбинированный критерий: все критерии или любой из них
+type combined struct {
+	criteria []criterion
+	any      bool
+}
+
+func (c combined) check(it iteration) string {
+	var reasons []string
+	for _, cr := range c.criteria {
+		reason := cr.check(it)
+		if reason == "" {
+			if !c.any {
+				return ""
+			}
+			continue
+		}
+		if c.any {
+			return reason
+		}
+		reasons = append(reasons, reason)
+	}
+	return strings.Join(reasons, " and ")
+}
+
+// Степенной метод с заданным критерием сходимости.
+// Возвращает описание сработавшего критерия.
+func pmWith(A operator, c criterion) (e eigen, stop string, err error) {
+	n := A.size()
+	if n == 0 {
+		err = fmt.Errorf("matrix size is zero")
+		return
+	}
+
+	it := iteration{
+		A:     A,
+		x:     make([]float64, n),
+		xLast: make([]float64, n),
+	}
+	if b, errB := spectralBounds(A); errB == nil {
+		it.norm = b.normF
+	}
+	if err = startFor(A, it.x); err != nil {
+		return
+	}
+
+	z := make([]float64, n)
+	for it.number = 1; ; it.number++ {
+		if it.number > pmMaxIteration {
+			err = fmt.Errorf("Iteration limit")
+			return
+		}
+
+		// z(k) = A · x(k-1)
+		A.mul(z, it.x)
+		copy(it.xLast, it.x)
+		it.lLast = it.l
+		if it.l, err = oneMax(it.x, z); err != nil {
+			return
+		}
+		if it.number < 3 {
+			continue
+		}
+
+		if stop = c.check(it); stop != "" {
+			break
+		}
+	}
+
+	if output {
+		fmt.Printf("pm: λ = %.14e, iterations = %d, stop: %s\n",
+			it.l, it.number, stop)
+	}
+	e = eigen{𝑿: it.x, 𝜦: it.l, iterations: it.number}
+	return
+}
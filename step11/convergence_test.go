@@ -0,0 +1,206 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCriterion(t *testing.T) {
+	defer harmonicStart()()
+
+	A := randomSymmetric(6, 5)
+	ej, err := jacobi(A, cyclicSweep)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tcs := []struct {
+		name string
+		c    criterion
+		stop string
+	}{
+		{name: "eigenvalue", c: valueChange{tol: 1e-14}, stop: "eigenvalue change"},
+		{name: "angle", c: angleChange{tol: 1e-10}, stop: "angle change"},
+		{name: "residual", c: residualChange{tol: 1e-12}, stop: "residual"},
+		{
+			name: "all",
+			c: combined{criteria: []criterion{
+				valueChange{tol: 1e-14},
+				residualChange{tol: 1e-12},
+			}},
+			stop: "eigenvalue change",
+		},
+		{
+			name: "any",
+			c: combined{criteria: []criterion{
+				residualChange{tol: 1e-12},
+				valueChange{tol: 1e-14},
+			}, any: true},
+			stop: "residual",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			e, stop, err := pmWith(dense(A), tc.c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("iterations = %d, stop: %s", e.iterations, stop)
+			if !strings.HasPrefix(stop, tc.stop) {
+				t.Errorf("stop: %s", stop)
+			}
+			if math.Abs(e.𝜦-ej[0].𝜦) > 1e-8*math.Abs(ej[0].𝜦) {
+				t.Errorf("%.14e != %.14e", e.𝜦, ej[0].𝜦)
+			}
+		})
+	}
+
+	// Для знакопеременной пары нормирующий множитель не меняется,
+	// хотя вектор не сходится
+	pair := dense{
+		{0, 1},
+		{1, 0},
+	}
+	if _, stop, err := pmWith(pair, valueChange{tol: 1e-14}); err != nil ||
+		!strings.HasPrefix(stop, "eigenvalue change") {
+		t.Errorf("eigenvalue change: %v %v", stop, err)
+	}
+	for _, c := range []criterion{
+		angleChange{tol: 1e-9},
+		residualChange{tol: 1e-12},
+		combined{criteria: []criterion{
+			valueChange{tol: 1e-14},
+			residualChange{tol: 1e-12},
+		}},
+	} {
+		if _, stop, err := pmWith(pair, c); err == nil {
+			t.Errorf("%T: error is not found. stop: %s", c, stop)
+		}
+	}
+}
+
+func TestSolverCriterion(t *testing.T) {
+	defer harmonicStart()()
+
+	A := spectrum([]float64{-5, 4, 2, 1, -0.5})
+
+	// исчерпывание Хотеллинга для матрицы с простыми собственными
+	// значениями, см. TestRefine
+	B := generator([]eigen{
+		{𝜦: +2.0, 𝑿: []float64{+0.5714286, +0.1428572, +1.0000000}},
+		{𝜦: -5.0, 𝑿: []float64{-0.6666667, -1.0000000, -1.0000000}},
+		{𝜦: -1.0, 𝑿: []float64{+0.5773503, +0.5773503, +0.5773503}},
+	})
+
+	solvers := []struct {
+		name  string
+		A     [][]float64
+		solve func() ([]eigen, error)
+	}{
+		{name: "pm", solve: func() ([]eigen, error) { return pm(A) }},
+		{name: "exh", A: B, solve: func() ([]eigen, error) {
+			old := initialize
+			initialize = func(x []float64) {
+				for i := range x {
+					x[i] = 1.0 + float64(i)
+				}
+			}
+			defer func() { initialize = old }()
+			return exh(B)
+		}},
+		{name: "exh implicit", solve: func() ([]eigen, error) {
+			old := deflation
+			deflation = implicit
+			defer func() { deflation = old }()
+			return exh(A)
+		}},
+		{name: "exh wielandt", solve: func() ([]eigen, error) {
+			old := deflation
+			deflation = wielandt
+			defer func() { deflation = old }()
+			return exh(A)
+		}},
+		{name: "exhSelect", solve: func() ([]eigen, error) {
+			return exhSelect(A, selector{which: largestAlgebraicValues, nev: 2})
+		}},
+		{name: "pmMulti", solve: func() ([]eigen, error) { return pmMulti(A) }},
+		{name: "chebyshev", solve: func() ([]eigen, error) {
+			e, err := chebyshev(dense(A), -0.6, 4.1)
+			return []eigen{e}, err
+		}},
+	}
+
+	for _, c := range []struct {
+		name string
+		c    criterion
+		stop string
+	}{
+		{name: "built-in"},
+		{name: "angle", c: angleChange{tol: 1e-10}, stop: "angle change"},
+		{name: "residual", c: residualChange{tol: 1e-12}, stop: "residual"},
+	} {
+		for _, s := range solvers {
+			t.Run(c.name+"/"+s.name, func(t *testing.T) {
+				old := convergence
+				convergence = c.c
+				defer func() { convergence = old }()
+
+				es, err := s.solve()
+				if err != nil {
+					t.Fatal(err)
+				}
+				M := A
+				if s.A != nil {
+					M = s.A
+				}
+				for i, e := range es {
+					// последняя пара Виландта находится без итераций
+					if e.stop == "matrix 1x1" && s.name == "exh wielandt" {
+						continue
+					}
+					if e.stop == "" || !strings.HasPrefix(e.stop, c.stop) {
+						t.Errorf("stop %d: %q", i, e.stop)
+					}
+					if r := residual(M, e); r > 1e-6 {
+						t.Errorf("residual %d: %.5e", i, r)
+					}
+				}
+			})
+		}
+	}
+
+	t.Run("number", func(t *testing.T) {
+		// номер итерации считается для каждого вектора отдельно
+		var c numbers
+		old := convergence
+		convergence = &c
+		defer func() { convergence = old }()
+		if _, err := exh(B); err != nil {
+			t.Fatal(err)
+		}
+		var starts int
+		for _, number := range c.seen {
+			if number < 3 {
+				t.Fatalf("criterion is checked at iteration %d", number)
+			}
+			if number == 3 {
+				starts++
+			}
+		}
+		// правый и левый вектор для каждого значения
+		if starts < 2*len(B) {
+			t.Errorf("iterations are counted for %d vectors", starts)
+		}
+	})
+}
+
+// numbers - критерий по невязке с записью номеров итераций
+type numbers struct {
+	seen []int64
+}
+
+func (c *numbers) check(it iteration) string {
+	c.seen = append(c.seen, it.number)
+	return residualChange{tol: 1e-12}.check(it)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Демпфирование по Рэлею
+//
+//	C = α·M + β·K
+//	ζ(ω) = α/(2·ω) + β·ω/2
+//
+// По двум формам с заданными ζ коэффициенты α, β находятся
+// точно, по большему количеству форм - методом наименьших
+// квадратов отклонений ζ.
+
+// заданный коэффициент демпфирования формы
+type dampingTarget struct {
+	mode int
+	ζ    float64
+}
+
+// подобранное демпфирование по Рэлею
+type rayleighFit struct {
+	α, β float64
+
+	// коэффициенты демпфирования всех форм
+	ζ []float64
+}
+
+// Подбор α, β по заданным коэффициентам демпфирования форм
+func rayleighDamping(modes []eigen, targets []dampingTarget) (r rayleighFit, err error) {
+	if len(targets) < 2 {
+		err = fmt.Errorf("amount of targets %d is less than 2", len(targets))
+		return
+	}
+	// нормальные уравнения для строк [1/(2·ω), ω/2]
+	var a11, a12, a22, b1, b2 float64
+	seen := map[int]bool{}
+	for _, t := range targets {
+		if t.mode < 0 || len(modes) <= t.mode {
+			err = fmt.Errorf("mode %d is not found", t.mode)
+			return
+		}
+		if seen[t.mode] {
+			err = fmt.Errorf("mode %d is repeated", t.mode)
+			return
+		}
+		seen[t.mode] = true
+		if t.ζ < 0.0 {
+			err = fmt.Errorf("damping ratio of mode %d is negative: %.5f", t.mode, t.ζ)
+			return
+		}
+		if modes[t.mode].𝜦 <= 0.0 {
+			err = fmt.Errorf("eigenvalue of mode %d is not positive: %.5e", t.mode, modes[t.mode].𝜦)
+			return
+		}
+		ω := math.Sqrt(modes[t.mode].𝜦)
+		p, q := 1.0/(2.0*ω), ω/2.0
+		a11 += p * p
+		a12 += p * q
+		a22 += q * q
+		b1 += p * t.ζ
+		b2 += q * t.ζ
+	}
+	det := a11*a22 - a12*a12
+	if math.Abs(det) <= 𝛆*a11*a22 {
+		err = fmt.Errorf("frequencies of targets are same")
+		return
+	}
+	r.α = (b1*a22 - b2*a12) / det
+	r.β = (a11*b2 - a12*b1) / det
+	r.ζ = rayleighRatios(modes, r.α, r.β)
+	if output {
+		fmt.Printf("rayleigh: α = %.14e, β = %.14e\n", r.α, r.β)
+		for i := range r.ζ {
+			fmt.Printf("mode %d: ω = %.5e, ζ = %.5f\n", i, math.Sqrt(math.Max(modes[i].𝜦, 0.0)), r.ζ[i])
+		}
+	}
+	return
+}
+
+// C = α·M + β·K
+func (r rayleighFit) matrix(K, M [][]float64) (C [][]float64) {
+	C = zeros(len(K))
+	for i := range C {
+		for j := range C[i] {
+			C[i][j] = r.α*M[i][j] + r.β*K[i][j]
+		}
+	}
+	return
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRayleighDamping(t *testing.T) {
+	K, M := chain(6, 1000, 2, true)
+	modes, err := generalized(K, M)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("two modes", func(t *testing.T) {
+		r, err := rayleighDamping(modes, []dampingTarget{{0, 0.02}, {4, 0.05}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(r.ζ[0]-0.02) > 1e-14 || math.Abs(r.ζ[4]-0.05) > 1e-14 {
+			t.Errorf("ζ = %v", r.ζ)
+		}
+		if len(r.ζ) != len(modes) {
+			t.Fatalf("amount of ratios: %d", len(r.ζ))
+		}
+
+		// φᵀ·C·φ = 2·ζ·ω для форм, нормированных по массе
+		C := r.matrix(K, M)
+		if err := checkSymmetric(C); err != nil {
+			t.Fatal(err)
+		}
+		Cφ := make([]float64, len(C))
+		for i, m := range modes {
+			dense(C).mul(Cφ, m.𝑿)
+			var c float64
+			for k := range Cφ {
+				c += m.𝑿[k] * Cφ[k]
+			}
+			if expect := 2 * r.ζ[i] * math.Sqrt(m.𝜦); math.Abs(c-expect) > 1e-10*expect {
+				t.Errorf("mode %d: φᵀ·C·φ = %v != %v", i, c, expect)
+			}
+		}
+	})
+
+	t.Run("least squares", func(t *testing.T) {
+		// точные ζ по Рэлею восстанавливаются
+		α, β := 0.3, 2e-3
+		ζ := rayleighRatios(modes, α, β)
+		var targets []dampingTarget
+		for i := range modes {
+			targets = append(targets, dampingTarget{i, ζ[i]})
+		}
+		r, err := rayleighDamping(modes, targets)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(r.α-α) > 1e-10*α || math.Abs(r.β-β) > 1e-10*β {
+			t.Errorf("α = %v, β = %v", r.α, r.β)
+		}
+
+		// постоянное ζ приближается с наименьшей ошибкой
+		for i := range targets {
+			targets[i].ζ = 0.05
+		}
+		if r, err = rayleighDamping(modes, targets); err != nil {
+			t.Fatal(err)
+		}
+		sum := func(α, β float64) (s float64) {
+			for i, z := range rayleighRatios(modes, α, β) {
+				s += (z - targets[i].ζ) * (z - targets[i].ζ)
+			}
+			return
+		}
+		best := sum(r.α, r.β)
+		for _, d := range [][2]float64{{1e-3, 0}, {-1e-3, 0}, {0, 1e-6}, {0, -1e-6}} {
+			if s := sum(r.α+d[0], r.β+d[1]); s < best {
+				t.Errorf("sum of squares is not minimal: %v < %v", s, best)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		rigid := []eigen{{𝜦: 0, 𝑿: []float64{1}}, {𝜦: 4, 𝑿: []float64{1}}}
+		for _, tc := range []struct {
+			modes   []eigen
+			targets []dampingTarget
+		}{
+			{modes, []dampingTarget{{0, 0.02}}},
+			{modes, []dampingTarget{{0, 0.02}, {9, 0.05}}},
+			{modes, []dampingTarget{{1, 0.02}, {1, 0.05}}},
+			{modes, []dampingTarget{{0, -0.02}, {1, 0.05}}},
+			{rigid, []dampingTarget{{0, 0.02}, {1, 0.05}}},
+		} {
+			if _, err := rayleighDamping(tc.modes, tc.targets); err == nil {
+				t.Errorf("error is not found: %v", tc.targets)
+			}
+		}
+	})
+}
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Метод исчерпывания
+//
+// Литература:
+// * Уилкинсон Дж. Алгебраическая проблема собственных значений. Глава 9
+// * Saad Y. Numerical methods for large eigenvalue problems. Chapter 4.2
+
+// способ исчерпывания
+type deflationMethod int
+
+const (
+	// A - λ·u·vᵀ, где v - левый собственный вектор.
+	// Размер матрицы не меняется.
+	hotelling deflationMethod = iota
+
+	// A - u·aᵢᵀ/uᵢ, где aᵢ - строка матрицы A.
+	// Строка i становится нулевой и удаляется вместе со столбцом i.
+	wielandt
+
+	// H·A·H, где H·u = σ·e₁ - отражение Хаусхолдера.
+	// Первая строка и столбец удаляются.
+	householder
+
+	// A - Σ λₖ·uₖ·vₖᵀ без изменения исходной матрицы.
+	// Поправки применяются при каждом умножении на вектор.
+	implicit
+)
+
+var deflation deflationMethod = hotelling
+
+// уровень исчерпывания с уменьшением размера матрицы
+type level struct {
+	// собственное значение уровня
+	λ float64
+
+	// собственный вектор уровня
+	u []float64
+
+	// wielandt: удаленный индекс, r = aᵢ/uᵢ
+	index int
+	r     []float64
+
+	// householder: вектор отражения и первая строка H·A·H
+	w []float64
+	b []float64
+}
+
+// собственная пара для уменьшенной матрицы
+func reduced(A [][]float64) (u []float64, l float64, stop string, err error) {
+	n := len(A)
+	u = make([]float64, n)
+
+	// для случая матрица 1х1
+	if n == 1 {
+		u[0] = 1.0
+		l = A[0][0]
+		stop = "matrix 1x1"
+		return
+	}
+
+	// все оставшиеся собственные значения равны нулю
+	isAllZeros := true
+	for row := 0; row < n && isAllZeros; row++ {
+		for col := 0; col < n; col++ {
+			if A[row][col] != 0.0 {
+				isAllZeros = false
+				break
+			}
+		}
+	}
+	if isAllZeros {
+		u[0] = 1.0
+		stop = "zero matrix"
+		return
+	}
+
+	// инициализация произвольным вектором
+	if err = startFor(dense(A), u); err != nil {
+		return
+	}
+	if _, stop, err = power(dense(A).mul, u); err != nil {
+		return
+	}
+	l = λ(A, u)
+	return
+}
+
+// исчерпывание Виландта
+func deflateWielandt(A [][]float64, u []float64, l float64) (B [][]float64, lv level) {
+	n := len(A)
+
+	// строка с наибольшим элементом собственного вектора
+	index := 0
+	for i := range u {
+		if math.Abs(u[i]) > math.Abs(u[index]) {
+			index = i
+		}
+	}
+
+	r := make([]float64, n)
+	for col := 0; col < n; col++ {
+		r[col] = A[index][col] / u[index]
+	}
+
+	// A1 = A - u·rᵀ без строки и столбца index
+	B = make([][]float64, 0, n-1)
+	for row := 0; row < n; row++ {
+		if row == index {
+			continue
+		}
+		b := make([]float64, 0, n-1)
+		for col := 0; col < n; col++ {
+			if col == index {
+				continue
+			}
+			b = append(b, A[row][col]-u[row]*r[col])
+		}
+		B = append(B, b)
+	}
+
+	lv = level{λ: l, u: u, index: index, r: r}
+	return
+}
+
+// исчерпывание преобразованием подобия Хаусхолдера
+func deflateHouseholder(A [][]float64, u []float64, l float64) (B [][]float64, lv level) {
+	n := len(A)
+
+	// w = u + sign(u₀)·||u||·e₁
+	var norm float64
+	for i := range u {
+		norm += u[i] * u[i]
+	}
+	norm = math.Sqrt(norm)
+	w := make([]float64, n)
+	copy(w, u)
+	if u[0] < 0 {
+		w[0] -= norm
+	} else {
+		w[0] += norm
+	}
+
+	C := householderSimilarity(A, w)
+
+	B = make([][]float64, n-1)
+	for row := 1; row < n; row++ {
+		B[row-1] = C[row][1:]
+	}
+
+	lv = level{λ: l, u: u, w: w, b: C[0][1:]}
+	return
+}
+
+// H·A·H, где H = I - 2·w·wᵀ/(wᵀ·w)
+func householderSimilarity(A [][]float64, w []float64) (C [][]float64) {
+	n := len(A)
+	var ww float64
+	for i := range w {
+		ww += w[i] * w[i]
+	}
+
+	// A·H = A - 2·(A·w)·wᵀ/(wᵀ·w)
+	Aw := make([]float64, n)
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			Aw[row] += A[row][col] * w[col]
+		}
+	}
+	C = make([][]float64, n)
+	for row := 0; row < n; row++ {
+		C[row] = make([]float64, n)
+		for col := 0; col < n; col++ {
+			C[row][col] = A[row][col] - 2*Aw[row]*w[col]/ww
+		}
+	}
+
+	// H·(A·H) = A·H - 2·w·(wᵀ·A·H)/(wᵀ·w)
+	wC := make([]float64, n)
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			wC[col] += w[row] * C[row][col]
+		}
+	}
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			C[row][col] -= 2 * w[row] * wC[col] / ww
+		}
+	}
+	return
+}
+
+// H·x = x - 2·w·(wᵀ·x)/(wᵀ·w)
+func householderApply(w, x []float64) {
+	var ww, wx float64
+	for i := range w {
+		ww += w[i] * w[i]
+		wx += w[i] * x[i]
+	}
+	for i := range x {
+		x[i] -= 2 * w[i] * wx / ww
+	}
+}
+
+// восстановление собственного вектора уменьшенной матрицы
+// в пространство исходной матрицы
+func restore(levels []level, y []float64, l float64) (x []float64) {
+	x = y
+	for k := len(levels) - 1; k >= 0; k-- {
+		lv := levels[k]
+		n := len(lv.u)
+		z := make([]float64, n)
+
+		// для кратных собственных значений поправка не нужна
+		factor := 0.0
+		if math.Abs(l-lv.λ) > 𝛆*math.Max(math.Abs(l), math.Abs(lv.λ)) {
+			factor = 1.0 / (l - lv.λ)
+		}
+
+		if lv.w == nil {
+			// wielandt: x = z + (rᵀ·z)/(μ-λ)·u
+			for i, j := 0, 0; i < n; i++ {
+				if i == lv.index {
+					continue
+				}
+				z[i] = x[j]
+				j++
+			}
+			var rz float64
+			for i := range z {
+				rz += lv.r[i] * z[i]
+			}
+			for i := range z {
+				z[i] += rz * factor * lv.u[i]
+			}
+		} else {
+			// householder: x = H·[α; y], α = (bᵀ·y)/(μ-λ)
+			var by float64
+			for i := range x {
+				by += lv.b[i] * x[i]
+			}
+			z[0] = by * factor
+			copy(z[1:], x)
+			householderApply(lv.w, z)
+		}
+		x = z
+	}
+	return
+}
+
+// Оператор с неявным исчерпыванием:
+// B·x = A·x - Σ λₖ·uₖ·(vₖᵀ·x), где vₖᵀ·uₖ = 1.
+// Для симметричного оператора vₖ = uₖ/(uₖᵀ·uₖ) и исчерпывание
+// выполняется проекцией B·x = P·A·P·x, P = I - Σ uₖ·vₖᵀ.
+// Проекция после умножения убирает ошибки округления
+// вдоль найденных векторов, которые для большого λₖ
+// (оператор (A - σ·I)⁻¹ при σ около собственного значения)
+// много больше остальной части A·x.
+type implicitDeflation struct {
+	A operator
+
+	// исчерпывание проекцией
+	symmetric bool
+
+	λ []float64
+	u [][]float64
+	v [][]float64
+}
+
+func (d *implicitDeflation) size() int {
+	return d.A.size()
+}
+
+// x = x - Σ uₖ·(vₖᵀ·x)
+func (d *implicitDeflation) project(x []float64) {
+	for k := range d.u {
+		var vx float64
+		for i := range x {
+			vx += d.v[k][i] * x[i]
+		}
+		for i := range x {
+			x[i] -= d.u[k][i] * vx
+		}
+	}
+}
+
+func (d *implicitDeflation) mul(y, x []float64) {
+	if d.symmetric {
+		p := make([]float64, len(x))
+		copy(p, x)
+		d.project(p)
+		d.A.mul(y, p)
+		d.project(y)
+		return
+	}
+	d.A.mul(y, x)
+	for k := range d.λ {
+		var vx float64
+		for i := range x {
+			vx += d.v[k][i] * x[i]
+		}
+		for i := range y {
+			y[i] -= d.λ[k] * d.u[k][i] * vx
+		}
+	}
+}
+
+func (d *implicitDeflation) mulT(y, x []float64) {
+	if d.symmetric {
+		d.mul(y, x)
+		return
+	}
+	d.A.(transposer).mulT(y, x)
+	for k := range d.λ {
+		var ux float64
+		for i := range x {
+			ux += d.u[k][i] * x[i]
+		}
+		for i := range y {
+			y[i] -= d.λ[k] * d.v[k][i] * ux
+		}
+	}
+}
+
+// Метод исчерпывания для оператора без изменения оператора.
+// Для операторов без умножения на транспонированную матрицу
+// оператор считается симметричным.
+// Количество собственных пар nev, при nev <= 0 находятся все.
+func exhOperator(A operator, nev int) (e []eigen, err error) {
+	return exhOperatorUntil(A, nev, nil)
+}
+
+// Неявное исчерпывание до nev собственных пар или до первой
+// пары, для которой stop возвращает true. Эта пара не входит
+// в результат. Пары возвращаются в порядке нахождения,
+// упорядочивание выполняет вызывающая функция.
+func exhOperatorUntil(A operator, nev int, stop func(e eigen) bool) (e []eigen, err error) {
+	n := A.size()
+	if n == 0 {
+		err = fmt.Errorf("matrix size is zero")
+		return
+	}
+	if nev <= 0 || nev > n {
+		nev = n
+	}
+
+	_, isTransposer := A.(transposer)
+	d := &implicitDeflation{A: A, symmetric: !isTransposer}
+
+	// Начальный вектор сдвигается по кругу для каждой пары,
+	// иначе в нем нет составляющей кратного собственного
+	// значения после исчерпывания найденного вектора.
+	// Вектор проверяется для итерируемого оператора op.
+	start := func(shift int, op operator) (x []float64, err error) {
+		x = make([]float64, n)
+		initialize(x)
+		shift %= n
+		x = append(x[n-shift:], x[:n-shift]...)
+		err = checkSolverStart(op, x)
+		return
+	}
+
+	for len(e) < nev {
+		// инициализация произвольным вектором,
+		// для знакопеременной пары находятся оба вектора
+		var u, second []float64
+		var reason string
+		if u, err = start(len(e), d); err != nil {
+			return
+		}
+		if second, reason, err = power(d.mul, u); err != nil {
+			return
+		}
+		us := [][]float64{u}
+		if second != nil {
+			us = append(us, second)
+		}
+
+		// левые собственные вектора
+		var vs [][]float64
+		if isTransposer {
+			var v []float64
+			if v, err = start(len(e), transposed{d}); err != nil {
+				return
+			}
+			if second, _, err = power(d.mulT, v); err != nil {
+				return
+			}
+			vs = [][]float64{v}
+			if second != nil {
+				vs = append(vs, second)
+			}
+		}
+
+		stopped := false
+		for _, u := range us {
+			if len(e) == nev {
+				break
+			}
+			l := rayleigh(A, u)
+			if stop != nil && stop(eigen{𝑿: u, 𝜦: l}) {
+				stopped = true
+				break
+			}
+
+			// левый вектор пары выбирается по наибольшему |cos(v,u)|,
+			// так как vᵀ·u = 0 для разных собственных значений
+			v := make([]float64, n)
+			copy(v, u)
+			if isTransposer {
+				best := 0
+				for k := range vs {
+					if math.Abs(cosine(vs[k], u)) > math.Abs(cosine(vs[best], u)) {
+						best = k
+					}
+				}
+				copy(v, vs[best])
+			}
+
+			// нормализация vᵀ·u = 1
+			var vu float64
+			for i := range u {
+				vu += v[i] * u[i]
+			}
+			if math.Abs(vu) < 𝛆 {
+				err = fmt.Errorf("check is not ok. V'*U = %.14e", vu)
+				return
+			}
+			for i := range v {
+				v[i] /= vu
+			}
+
+			d.λ = append(d.λ, l)
+			d.u = append(d.u, u)
+			d.v = append(d.v, v)
+
+			x := make([]float64, n)
+			if _, err = oneMax(x, u); err != nil {
+				return
+			}
+			e = append(e, eigen{𝑿: x, 𝜦: l, stop: reason})
+		}
+		if stopped {
+			break
+		}
+	}
+	return
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestDeflation(t *testing.T) {
+	defer harmonicStart()()
+
+	tcs := []struct {
+		name   string
+		A      [][]float64
+		values []float64
+	}{
+		{
+			name: "simple",
+			A: generator([]eigen{
+				{𝜦: +2.0, 𝑿: []float64{+0.5714286, +0.1428572, +1.0000000}},
+				{𝜦: -5.0, 𝑿: []float64{-0.6666667, -1.0000000, -1.0000000}},
+				{𝜦: -1.0, 𝑿: []float64{+0.5773503, +0.5773503, +0.5773503}},
+			}),
+			values: []float64{-5, 2, -1},
+		},
+		{
+			name: "D",
+			A: [][]float64{
+				{17, -2, -2},
+				{-2, 14, -4},
+				{-2, -4, 14},
+			},
+			values: []float64{18, 18, 9},
+		},
+		{
+			name: "Большие числа",
+			A: [][]float64{
+				{1e12, 0, 0, 0},
+				{0, 1e8, 0, 0},
+				{0, 0, 1e4, 0},
+				{0, 0, 0, 1e1},
+			},
+			values: []float64{1e12, 1e8, 1e4, 1e1},
+		},
+		{
+			name: "symmetric 5x5",
+			A: [][]float64{
+				{4, 1, 0, 0, 0},
+				{1, 3, 1, 0, 0},
+				{0, 1, 2, 1, 0},
+				{0, 0, 1, 1, 1},
+				{0, 0, 0, 1, 0},
+			},
+		},
+	}
+
+	methods := []struct {
+		name   string
+		method deflationMethod
+	}{
+		{name: "wielandt", method: wielandt},
+		{name: "householder", method: householder},
+		{name: "implicit", method: implicit},
+	}
+
+	for _, m := range methods {
+		t.Run(m.name, func(t *testing.T) {
+			oldDeflation := deflation
+			deflation = m.method
+			defer func() {
+				deflation = oldDeflation
+			}()
+
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					A := make([][]float64, len(tc.A))
+					for i := range A {
+						A[i] = append([]float64{}, tc.A[i]...)
+					}
+
+					e, err := exh(A)
+					PrintEigens(e)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if len(e) != len(tc.A) {
+						t.Fatalf("amount of eigenvalues: %d != %d", len(e), len(tc.A))
+					}
+
+					values := tc.values
+					if values == nil {
+						ej, err := jacobi(tc.A, cyclicSweep)
+						if err != nil {
+							t.Fatal(err)
+						}
+						for i := range ej {
+							values = append(values, ej[i].𝜦)
+						}
+					}
+
+					for i := range A {
+						for j := range A[i] {
+							if A[i][j] != tc.A[i][j] {
+								t.Fatalf("input matrix is changed")
+							}
+						}
+					}
+
+					for i := range e {
+						if math.Abs(e[i].𝜦-values[i]) > 1e-8*math.Abs(values[i]) {
+							t.Errorf("eigenvalue %d: %.14e != %.14e", i, e[i].𝜦, values[i])
+						}
+						if d := residual(tc.A, e[i]); d > 1e-6*math.Abs(values[0]) {
+							t.Errorf("residual %d: %.5e", i, d)
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	// несимметричная матрица с известными собственными значениями
+	A := generator([]eigen{
+		{𝜦: +4.0, 𝑿: []float64{+1.0, +0.5, +0.2, +0.1}},
+		{𝜦: -3.0, 𝑿: []float64{+0.3, +1.0, -0.5, +0.2}},
+		{𝜦: +2.0, 𝑿: []float64{-0.2, +0.4, +1.0, +0.6}},
+		{𝜦: +1.0, 𝑿: []float64{+0.1, -0.3, +0.2, +1.0}},
+	})
+	values := []float64{4, -3, 2, 1}
+
+	for _, method := range []deflationMethod{wielandt, householder} {
+		// вектора проверяются по невязке для исходной матрицы
+		var levels []level
+		B := A
+		var found []float64
+		for len(B) > 1 {
+			u, l := dominant(B)
+			x := restore(levels, u, l)
+			if d := residual(A, eigen{𝜦: l, 𝑿: x}); d > 1e-9 {
+				t.Errorf("method %d. residual for %.5f: %.5e", method, l, d)
+			}
+			found = append(found, l)
+			var lv level
+			if method == wielandt {
+				B, lv = deflateWielandt(B, u, l)
+			} else {
+				B, lv = deflateHouseholder(B, u, l)
+			}
+			levels = append(levels, lv)
+		}
+		found = append(found, B[0][0])
+		if d := residual(A, eigen{𝜦: B[0][0], 𝑿: restore(levels, []float64{1}, B[0][0])}); d > 1e-9 {
+			t.Errorf("method %d. residual for last: %.5e", method, d)
+		}
+
+		sort.Sort(sort.Reverse(sort.Float64Slice(found)))
+		sort.Sort(sort.Reverse(sort.Float64Slice(values)))
+		for i := range found {
+			if math.Abs(found[i]-values[i]) > 1e-9 {
+				t.Errorf("method %d. eigenvalue %d: %.14e != %.14e", method, i, found[i], values[i])
+			}
+		}
+	}
+}
+
+// наибольшая по модулю собственная пара
+func dominant(A [][]float64) (u []float64, l float64) {
+	n := len(A)
+	u = make([]float64, n)
+	for i := range u {
+		u[i] = 1.0 + float64(i)
+	}
+	z := make([]float64, n)
+	for iter := 0; iter < 2000; iter++ {
+		for row := 0; row < n; row++ {
+			z[row] = 0.0
+			for col := 0; col < n; col++ {
+				z[row] += A[row][col] * u[col]
+			}
+		}
+		oneMax(u, z)
+	}
+	l = λ(A, u)
+	return
+}
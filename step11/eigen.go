@@ -12,6 +12,15 @@ type eigen struct {
 
 	// собственный вектор
 	𝑿 []float64
+
+	// оценка отношения |λ2|/|λ1| по скорости сходимости
+	ratio float64
+
+	// количество итераций
+	iterations int64
+
+	// сработавший критерий сходимости
+	stop string
 }
 
 func (e eigen) String() (out string) {
@@ -64,3 +73,46 @@ func compare(e, es []eigen) bool {
 	isSameEigen := found == len(e) && found == len(es)
 	return isSameEigen
 }
+
+// проверка входной матрицы
+func checkInput(A [][]float64) (err error) {
+	n := len(A)
+	if n == 0 {
+		err = fmt.Errorf("matrix size is zero")
+		return
+	}
+
+	// проверка на квадратность входной матрицы
+	for row := 0; row < len(A); row++ {
+		if len(A[row]) != n {
+			err = fmt.Errorf("input matrix is not square in row %d: [%d,%d]", row, n, len(A[row]))
+			return
+		}
+	}
+
+	// матрица А не должна состоять из одних нулей
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			if A[row][col] != 0.0 {
+				return
+			}
+		}
+	}
+	err = fmt.Errorf("all elements of matrix is zeros")
+	return
+}
+
+// проверка симметричности матрицы
+func checkSymmetric(A [][]float64) (err error) {
+	for row := range A {
+		for col := row + 1; col < len(A); col++ {
+			a, b := A[row][col], A[col][row]
+			if math.Abs(a-b) > 𝛆*100*(math.Abs(a)+math.Abs(b)) {
+				err = fmt.Errorf("matrix is not symmetric in [%d,%d]: %.14e != %.14e",
+					row, col, A[row][col], A[col][row])
+				return
+			}
+		}
+	}
+	return
+}
@@ -9,34 +9,18 @@ import (
 
 func exh(A [][]float64) (e []eigen, err error) {
 	n := len(A)
-	if n == 0 {
-		err = fmt.Errorf("matrix size is zero")
+	if err = checkInput(A); err != nil {
 		return
 	}
 
-	// проверка на квадратность входной матрицы
-	for row := 0; row < len(A); row++ {
-		if len(A[row]) != n {
-			err = fmt.Errorf("input matrix is not square in row %d: [%d,%d]", row, n, len(A[row]))
-			return
-		}
-	}
-
-	// матрица А не должна состоять из одних нулей
-	{
-		isAllZeros := true
-		for row := 0; row < n; row++ {
-			for col := 0; col < n; col++ {
-				if A[row][col] != 0.0 {
-					isAllZeros = false
-					break
-				}
+	// автоматический сдвиг спектра
+	A, σ := shifting.apply(A)
+	if σ != 0.0 {
+		defer func() {
+			for i := range e {
+				e[i].𝜦 += σ
 			}
-		}
-		if isAllZeros {
-			err = fmt.Errorf("all elements of matrix is zeros")
-			return
-		}
+		}()
 	}
 
 	// для случая матрица 1х1
@@ -50,6 +34,27 @@ func exh(A [][]float64) (e []eigen, err error) {
 		return
 	}
 
+	// неявное исчерпывание без изменения матрицы
+	if deflation == implicit {
+		e, err = exhOperator(dense(A), n)
+		if err == nil && refinement {
+			err = refine(A, e)
+		}
+		if err == nil {
+			err = sortOrder(e)
+		}
+		if err == nil {
+			err = debugBounds(A, e)
+		}
+		if err == nil {
+			err = normalizing.apply(e)
+		}
+		return
+	}
+
+	// исходная матрица для уточнения собственных пар
+	input := A
+
 	// add random seed
 	rand.Seed(time.Now().UnixNano())
 
@@ -57,8 +62,21 @@ func exh(A [][]float64) (e []eigen, err error) {
 	var maxIteration int64 = 5000
 	var iter int64 = 0
 
-	get := func(x []float64, trans bool) (err error) {
-		for max, maxLast, z := 0.0, 0.0, make([]float64, n); ; {
+	// Для знакопеременной пары ±λ вектор x(k) повторяется через
+	// итерацию, тогда вектора пары разделяются в powerPair:
+	// x - вектор для l, second - вектор для -l.
+	get := func(x []float64, trans bool) (second []float64, l float64, stop string, err error) {
+		mul := dense(A).mul
+		var op operator = dense(A)
+		if trans {
+			op = transposed{dense(A)}
+			mul = op.(transposed).mul
+		}
+		xLast := make([]float64, n)
+		xPrev2 := make([]float64, n)
+		change := math.MaxFloat64
+		restarted := false
+		for number, max, maxLast, z := int64(1), 0.0, 0.0, make([]float64, n); ; number++ {
 			// устанавливаем лимит на количество итераций
 			iter++
 			if iter > maxIteration {
@@ -81,6 +99,8 @@ func exh(A [][]float64) (e []eigen, err error) {
 			}
 
 			// x(k) = z(k) / || z(k) ||
+			copy(xPrev2, xLast)
+			copy(xLast, x)
 			max, err = oneMax(x, z)
 			if err != nil {
 				return
@@ -95,109 +115,235 @@ func exh(A [][]float64) (e []eigen, err error) {
 				fmt.Printf("\t𝛆 = %10.5e\n", math.Abs((max-maxLast)/max))
 			}
 
+			// знакопеременная пара ±λ
+			changeLast := change
+			var change2 float64
+			change = 0.0
+			for i := range x {
+				change = math.Max(change, math.Abs(x[i]-xLast[i]))
+				change2 = math.Max(change2, math.Abs(x[i]-xPrev2[i]))
+			}
+			if number > 2 && change > math.Sqrt(𝛆) && change2 <= math.Sqrt(𝛆)*change {
+				if output {
+					fmt.Println("exh: sign-alternating pair")
+				}
+				l, second, stop, err = powerPair(mul, x)
+				stop = "sign-alternating pair, " + stop
+				return
+			}
+
+			// заданный критерий сходимости
+			if convergence != nil {
+				it := iteration{A: op, x: x, xLast: xLast, l: max, lLast: maxLast, number: number}
+				if number >= 3 {
+					if stop = convergence.check(it); stop != "" {
+						break
+					}
+				}
+				maxLast = max
+				continue
+			}
+
 			// ||x(k-1)-x(k-2)|| > 𝛆
 			if iter > 0 {
 				if math.Abs((max-maxLast)/max) < 𝛆 { // eMax(x, xLast) < 𝛆
-					if iter < 3 {
+					if number <= 3 && !restarted {
 						// на случай слишком быстрой сходимости
+						restarted = true
 						random(x)
 						continue
 					}
+					// нормирующий множитель знакопеременной пары
+					// не меняется, поэтому итерации идут до сходимости
+					// вектора или до его повторения через итерацию
+					if number < 3 || change > math.Sqrt(𝛆) {
+						maxLast, max = max, maxLast
+						continue
+					}
 
 					// проверка результата, выходим из итераций
+					stop = fmt.Sprintf("eigenvalue change %.5e", math.Abs((max-maxLast)/max))
 					break
 				}
 			}
 
+			// вектор сошелся до уровня ошибок округления, а
+			// нормирующий множитель меняется на несколько 𝛆
+			if number >= 3 && change < math.Sqrt(𝛆) && change >= changeLast {
+				if number <= 3 && !restarted {
+					// начальный вектор - собственный вектор
+					// не обязательно наибольшего по модулю значения
+					restarted = true
+					random(x)
+					continue
+				}
+				stop = fmt.Sprintf("vector change %.5e", change)
+				break
+			}
+
 			maxLast, max = max, maxLast
 		}
 		return
 	}
 
-	for value := 0; value < n; {
+	// уровни исчерпывания для восстановления собственных векторов
+	var levels []level
+
+	// Количество найденных значений по Gauss зависит от точности λ,
+	// поэтому собственных пар не больше размера матрицы
+	for value := 0; value < n && len(e) < n; {
 		if output {
 			fmt.Println("Input A. value = ", value)
 			MatrixPrint(A)
 		}
 
-		// инициализация произвольным вектором
-		u := make([]float64, n)
-		initialize(u)
-		err = get(u, false)
-		if err != nil {
-			return
-		}
-
-		l := λ(A, u)
-
-		value += Gauss(A, l)
-
-		e = append(e, eigen{𝑿: u, 𝜦: l})
+		// исчерпывание с уменьшением размера матрицы
+		if deflation != hotelling {
+			var u []float64
+			var l float64
+			var stop string
+			u, l, stop, err = reduced(A)
+			if err != nil {
+				return
+			}
+			e = append(e, eigen{𝑿: restore(levels, u, l), 𝜦: l, stop: stop})
+			value++
+			if value == n {
+				break
+			}
 
-		// инициализация произвольным вектором
-		v := make([]float64, n)
-		initialize(v)
-		err = get(v, true)
-		if err != nil {
-			return
+			var lv level
+			if deflation == wielandt {
+				A, lv = deflateWielandt(A, u, l)
+			} else {
+				A, lv = deflateHouseholder(A, u, l)
+			}
+			levels = append(levels, lv)
+			continue
 		}
 
-		// нормализация
-		_, err = oneMax(u, u)
-		if err != nil {
+		// инициализация произвольным вектором,
+		// для знакопеременной пары находятся оба вектора
+		u := make([]float64, n)
+		if err = startFor(dense(A), u); err != nil {
 			return
 		}
-		_, err = oneMax(v, v)
-		if err != nil {
+		second, pairValue, stop, errG := get(u, false)
+		if err = errG; err != nil {
 			return
 		}
-		var pro float64
-		for i := range u {
-			pro += u[i] * v[i]
+		us := [][]float64{u}
+		ls := []float64{λ(A, u)}
+		if second != nil {
+			us = append(us, second)
+			ls = []float64{pairValue, -pairValue}
 		}
-		for i := range u {
-			v[i] /= pro
+		for k := range us {
+			e = append(e, eigen{𝑿: us[k], 𝜦: ls[k], stop: stop})
+		}
+		if second == nil {
+			value += Gauss(A, ls[0])
+		} else {
+			// пара различных простых собственных значений
+			value += 2
 		}
 
-		// проверка V'*U = 1
-		{
-			res := 0.0
-			for i := range u {
-				res += v[i] * u[i]
-			}
-			if math.Abs(res) > 1+1e-1 || math.Abs(res) < 1-1e-1 {
-				err = fmt.Errorf("check is not ok. V'*U = %.14e != 1\nu = %v\nv = %v",
-					res, u, v)
-				return
-			}
+		// инициализация произвольным вектором
+		v := make([]float64, n)
+		if err = startFor(transposed{dense(A)}, v); err != nil {
+			return
+		}
+		if second, _, _, err = get(v, true); err != nil {
+			return
+		}
+		vs := [][]float64{v}
+		if second != nil {
+			vs = append(vs, second)
 		}
 
 		// метод исчерпывания
 		Atmp := make([][]float64, n)
 		for i := 0; i < n; i++ {
 			Atmp[i] = make([]float64, n)
+			copy(Atmp[i], A[i])
 		}
+		for k, u := range us {
+			// левый вектор пары выбирается по наибольшему |cos(v,u)|,
+			// так как vᵀ·u = 0 для разных собственных значений
+			best := 0
+			for m := range vs {
+				if math.Abs(cosine(vs[m], u)) > math.Abs(cosine(vs[best], u)) {
+					best = m
+				}
+			}
+			v := make([]float64, n)
+			copy(v, vs[best])
+
+			// нормализация
+			_, err = oneMax(u, u)
+			if err != nil {
+				return
+			}
+			_, err = oneMax(v, v)
+			if err != nil {
+				return
+			}
+			var pro float64
+			for i := range u {
+				pro += u[i] * v[i]
+			}
+			for i := range u {
+				v[i] /= pro
+			}
+
+			// проверка V'*U = 1
+			{
+				res := 0.0
+				for i := range u {
+					res += v[i] * u[i]
+				}
+				if math.Abs(res) > 1+1e-1 || math.Abs(res) < 1-1e-1 {
+					err = fmt.Errorf("check is not ok. V'*U = %.14e != 1\nu = %v\nv = %v",
+						res, u, v)
+					return
+				}
+			}
 
-		for row := 0; row < n; row++ {
-			for col := 0; col < n; col++ {
-				Atmp[row][col] = A[row][col] - l*u[row]*v[col]
+			for row := 0; row < n; row++ {
+				for col := 0; col < n; col++ {
+					Atmp[row][col] -= ls[k] * u[row] * v[col]
+				}
 			}
 		}
 
 		A = Atmp
 	}
 
+	if err == nil && refinement {
+		err = refine(input, e)
+	}
+	if err == nil {
+		err = sortOrder(e)
+	}
+	if err == nil {
+		err = debugBounds(input, e)
+	}
+	if err == nil {
+		err = normalizing.apply(e)
+	}
+	return
+}
+
+// Нормализация и упорядочивание собственных пар по убыванию
+// модуля. При исчерпывании с неточными парами порядок
+// нахождения может отличаться от убывания модуля.
+func sortOrder(e []eigen) (err error) {
 	for i := range e {
-		oneMax(e[i].𝑿, e[i].𝑿)
-		if i == 0 {
-			continue
-		}
-		if math.Abs(e[i-1].𝜦)+𝛆 < math.Abs(e[i].𝜦) {
-			err = fmt.Errorf("eigen values is not less. %.14e !> %.14e",
-				math.Abs(e[i-1].𝜦), math.Abs(e[i].𝜦))
+		if _, err = oneMax(e[i].𝑿, e[i].𝑿); err != nil {
+			return
 		}
 	}
-
+	selector{which: largestMagnitude}.sort(e)
 	return
 }
 
@@ -354,7 +500,9 @@ func Gauss(A [][]float64, l float64) int {
 		fmt.Println("++++++++++++++")
 	}
 
-	fmt.Println("// TODO : ADD IMPLEMENTATION FOR КРАТНЫЕ СОБСТВЕННЫЕ ЗНАЧЕНИЯ")
+	if output {
+		fmt.Println("// TODO : ADD IMPLEMENTATION FOR КРАТНЫЕ СОБСТВЕННЫЕ ЗНАЧЕНИЯ")
+	}
 
 	return amount
 }
@@ -73,22 +73,25 @@ func ExampleSimple() {
 	// 𝑿[  2] = +5.7735030000e-01
 	// After
 	// ---     0 ---
-	// 𝜦      = -4.9999995141e+00
-	// 𝑿[  0] = +6.6666668939e-01
-	// 𝑿[  1] = +9.9999990456e-01
+	// 𝜦      = -5.0000000000e+00
+	// 𝑿[  0] = +6.6666670000e-01
+	// 𝑿[  1] = +1.0000000000e+00
 	// 𝑿[  2] = +1.0000000000e+00
 	// ---     1 ---
-	// 𝜦      = +1.9999997398e+00
-	// 𝑿[  0] = +5.7142183122e-01
-	// 𝑿[  1] = +1.4279607663e-01
+	// 𝜦      = +2.0000000000e+00
+	// 𝑿[  0] = +5.7142860000e-01
+	// 𝑿[  1] = +1.4285720000e-01
 	// 𝑿[  2] = +1.0000000000e+00
 	// ---     2 ---
 	// 𝜦      = -1.0000000000e+00
-	// 𝑿[  0] = +9.9999999640e-01
+	// 𝑿[  0] = +1.0000000000e+00
 	// 𝑿[  1] = +1.0000000000e+00
-	// 𝑿[  2] = +9.9999992052e-01
+	// 𝑿[  2] = +1.0000000000e+00
+	// Compare [  0,  0] is not same
 	// Compare [  0,  1] is same
 	// Compare [  1,  0] is same
+	// Compare [  2,  0] is not same
+	// Compare [  2,  1] is not same
 	// Compare [  2,  2] is same
 	// true
 }
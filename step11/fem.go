@@ -0,0 +1,385 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Сборка матриц жесткости K и масс M простой стержневой модели
+//
+// Элементы:
+//   - пружина с жесткостью k вдоль линии узлов;
+//   - стержень фермы с жесткостью E·A/L;
+//   - балка Эйлера-Бернулли плоской рамы (u, v, θ в узле).
+//
+// Матрица масс согласованная или сосредоточенная. Сосредоточенная
+// матрица получается по методу HRZ: диагональ согласованной
+// матрицы масштабируется так, чтобы сумма масс по направлению
+// перемещения равнялась массе элемента ρ·A·L. Для балки масса
+// поворота в узле ρ·A·L³/78.
+//
+// Опоры и связи между степенями свободы учитываются
+// преобразованием матриц, см. constraints.
+
+// степени свободы узла
+type modelKind int
+
+const (
+	// u
+	axialModel modelKind = iota
+
+	// u, v
+	planeTruss
+
+	// u, v, w
+	spaceTruss
+
+	// u, v, θ
+	planeFrame
+)
+
+// вид элемента
+type elementKind int
+
+const (
+	springElement elementKind = iota
+	trussElement
+	beamElement
+)
+
+// узел
+type node struct {
+	x, y, z float64
+}
+
+// элемент между узлами n1 и n2
+type element struct {
+	kind   elementKind
+	n1, n2 int
+
+	// жесткость пружины
+	k float64
+
+	// модуль упругости, площадь, момент инерции, плотность
+	E, A, I, ρ float64
+}
+
+// закрепленная степень свободы узла
+type support struct {
+	node, dof int
+}
+
+// сосредоточенная масса в узле по направлениям перемещения
+type pointMass struct {
+	node int
+	m    float64
+}
+
+// стержневая модель
+type model struct {
+	kind     modelKind
+	nodes    []node
+	elements []element
+	supports []support
+	links    []mpc
+	masses   []pointMass
+
+	// сосредоточенная матрица масс
+	lumped bool
+}
+
+// количество степеней свободы узла
+func (m model) dofs() int {
+	switch m.kind {
+	case axialModel:
+		return 1
+	case planeTruss:
+		return 2
+	}
+	return 3
+}
+
+// количество направлений перемещения узла
+func (m model) translations() int {
+	if m.kind == planeFrame {
+		return 2
+	}
+	return m.dofs()
+}
+
+// Полные матрицы K и M без учета опор
+func (m model) assemble() (K, M [][]float64, err error) {
+	if len(m.nodes) == 0 {
+		err = fmt.Errorf("nodes are not found")
+		return
+	}
+	n := len(m.nodes) * m.dofs()
+	K = make([][]float64, n)
+	M = make([][]float64, n)
+	for i := range K {
+		K[i] = make([]float64, n)
+		M[i] = make([]float64, n)
+	}
+
+	for i, el := range m.elements {
+		var index []int
+		var ke, me [][]float64
+		index, ke, me, err = m.element(el)
+		if err != nil {
+			err = fmt.Errorf("element %d: %v", i, err)
+			return
+		}
+		for a := range index {
+			for b := range index {
+				K[index[a]][index[b]] += ke[a][b]
+				M[index[a]][index[b]] += me[a][b]
+			}
+		}
+	}
+
+	for _, pm := range m.masses {
+		if pm.node < 0 || len(m.nodes) <= pm.node {
+			err = fmt.Errorf("point mass at node %d is outside of model", pm.node)
+			return
+		}
+		for d := 0; d < m.translations(); d++ {
+			i := pm.node*m.dofs() + d
+			M[i][i] += pm.m
+		}
+	}
+	return
+}
+
+// Матрицы элемента в глобальных координатах и номера
+// степеней свободы
+func (m model) element(el element) (index []int, ke, me [][]float64, err error) {
+	if el.n1 < 0 || len(m.nodes) <= el.n1 || el.n2 < 0 || len(m.nodes) <= el.n2 || el.n1 == el.n2 {
+		err = fmt.Errorf("nodes %d and %d are not valid", el.n1, el.n2)
+		return
+	}
+	p1, p2 := m.nodes[el.n1], m.nodes[el.n2]
+	d := []float64{p2.x - p1.x, p2.y - p1.y, p2.z - p1.z}
+	L := math.Sqrt(d[0]*d[0] + d[1]*d[1] + d[2]*d[2])
+	if L == 0.0 {
+		err = fmt.Errorf("length of element is zero")
+		return
+	}
+	t := m.translations()
+	c := make([]float64, t) // направляющие косинусы
+	for i := range c {
+		c[i] = d[i] / L
+	}
+	if m.kind == axialModel && (d[1] != 0 || d[2] != 0) {
+		err = fmt.Errorf("element is not on axis x")
+		return
+	}
+	if (m.kind == planeFrame || m.kind == planeTruss) && d[2] != 0 {
+		err = fmt.Errorf("element is not in plane xy")
+		return
+	}
+
+	switch el.kind {
+	case springElement, trussElement:
+		k := el.k
+		mass := 0.0
+		if el.kind == trussElement {
+			k = el.E * el.A / L
+			mass = el.ρ * el.A * L
+		}
+		if k <= 0 {
+			err = fmt.Errorf("stiffness is not positive: %.5e", k)
+			return
+		}
+		// перемещения узлов
+		for node := 0; node < 2; node++ {
+			for i := 0; i < t; i++ {
+				index = append(index, []int{el.n1, el.n2}[node]*m.dofs()+i)
+			}
+		}
+		ke = zeros(2 * t)
+		me = zeros(2 * t)
+		for i := 0; i < t; i++ {
+			for j := 0; j < t; j++ {
+				v := k * c[i] * c[j]
+				ke[i][j], ke[t+i][t+j] = v, v
+				ke[i][t+j], ke[t+i][j] = -v, -v
+			}
+			if m.lumped {
+				me[i][i] = mass / 2.0
+				me[t+i][t+i] = mass / 2.0
+			} else {
+				me[i][i], me[t+i][t+i] = mass/3.0, mass/3.0
+				me[i][t+i], me[t+i][i] = mass/6.0, mass/6.0
+			}
+		}
+
+	case beamElement:
+		if m.kind != planeFrame {
+			err = fmt.Errorf("beam is supported only in plane frame")
+			return
+		}
+		for _, nd := range []int{el.n1, el.n2} {
+			for i := 0; i < 3; i++ {
+				index = append(index, nd*3+i)
+			}
+		}
+		kl, ml := beamLocal(el, L, m.lumped)
+		// T - поворот из глобальных в местные координаты
+		T := zeros(6)
+		for b := 0; b < 2; b++ {
+			T[3*b][3*b], T[3*b][3*b+1] = c[0], c[1]
+			T[3*b+1][3*b], T[3*b+1][3*b+1] = -c[1], c[0]
+			T[3*b+2][3*b+2] = 1.0
+		}
+		ke = rotate(kl, T)
+		me = rotate(ml, T)
+
+	default:
+		err = fmt.Errorf("element kind %d is not supported", el.kind)
+	}
+	return
+}
+
+// матрицы балки в местных координатах (u1, v1, θ1, u2, v2, θ2)
+func beamLocal(el element, L float64, lumped bool) (k, m [][]float64) {
+	k = zeros(6)
+	m = zeros(6)
+
+	ea := el.E * el.A / L
+	k[0][0], k[3][3] = ea, ea
+	k[0][3], k[3][0] = -ea, -ea
+
+	ei := el.E * el.I / (L * L * L)
+	bend := [][]float64{
+		{12, 6 * L, -12, 6 * L},
+		{6 * L, 4 * L * L, -6 * L, 2 * L * L},
+		{-12, -6 * L, 12, -6 * L},
+		{6 * L, 2 * L * L, -6 * L, 4 * L * L},
+	}
+	index := []int{1, 2, 4, 5}
+	for a := range index {
+		for b := range index {
+			k[index[a]][index[b]] = ei * bend[a][b]
+		}
+	}
+
+	mass := el.ρ * el.A * L
+	if lumped {
+		for _, i := range []int{0, 1, 3, 4} {
+			m[i][i] = mass / 2.0
+		}
+		m[2][2] = mass * L * L / 78.0
+		m[5][5] = mass * L * L / 78.0
+		return
+	}
+	m[0][0], m[3][3] = mass/3.0, mass/3.0
+	m[0][3], m[3][0] = mass/6.0, mass/6.0
+	cons := [][]float64{
+		{156, 22 * L, 54, -13 * L},
+		{22 * L, 4 * L * L, 13 * L, -3 * L * L},
+		{54, 13 * L, 156, -22 * L},
+		{-13 * L, -3 * L * L, -22 * L, 4 * L * L},
+	}
+	for a := range index {
+		for b := range index {
+			m[index[a]][index[b]] = mass / 420.0 * cons[a][b]
+		}
+	}
+	return
+}
+
+// Tᵀ·A·T
+func rotate(A, T [][]float64) (B [][]float64) {
+	n := len(A)
+	AT := zeros(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			for k := 0; k < n; k++ {
+				AT[i][j] += A[i][k] * T[k][j]
+			}
+		}
+	}
+	B = zeros(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			for k := 0; k < n; k++ {
+				B[i][j] += T[k][i] * AT[k][j]
+			}
+		}
+	}
+	return
+}
+
+// нулевая матрица n x n
+func zeros(n int) (A [][]float64) {
+	A = make([][]float64, n)
+	for i := range A {
+		A[i] = make([]float64, n)
+	}
+	return
+}
+
+// номера степеней свободы модели
+func (m model) dofMap() dofMap {
+	dm := dofMap{}
+	for nd := range m.nodes {
+		for dir := 0; dir < m.dofs(); dir++ {
+			dm[dof{node: nd, dir: dir}] = nd*m.dofs() + dir
+		}
+	}
+	return dm
+}
+
+// ограничения модели по опорам и связям
+func (m model) constraints() (cs constraints) {
+	cs.dofs = m.dofMap()
+	for _, s := range m.supports {
+		cs.fixed = append(cs.fixed, dof{node: s.node, dir: s.dof})
+	}
+	cs.links = m.links
+	return
+}
+
+// Жесткая связь узла slave с узлом master.
+// Для плоской рамы:
+//
+//	u[s] = u[m] - (y[s] - y[m])·θ[m]
+//	v[s] = v[m] + (x[s] - x[m])·θ[m]
+//	θ[s] = θ[m]
+//
+// Для фермы и одномерной модели перемещения равны.
+func (m model) rigidLink(master, slave int) (links []mpc, err error) {
+	if master < 0 || len(m.nodes) <= master || slave < 0 || len(m.nodes) <= slave || master == slave {
+		err = fmt.Errorf("nodes %d and %d are not valid", master, slave)
+		return
+	}
+	if m.kind != planeFrame {
+		for dir := 0; dir < m.dofs(); dir++ {
+			links = append(links, mpc{
+				slave:   dof{node: slave, dir: dir},
+				masters: []dof{{node: master, dir: dir}},
+				c:       []float64{1},
+			})
+		}
+		return
+	}
+	dx := m.nodes[slave].x - m.nodes[master].x
+	dy := m.nodes[slave].y - m.nodes[master].y
+	θ := dof{node: master, dir: 2}
+	links = []mpc{
+		{slave: dof{slave, 0}, masters: []dof{{master, 0}, θ}, c: []float64{1, -dy}},
+		{slave: dof{slave, 1}, masters: []dof{{master, 1}, θ}, c: []float64{1, dx}},
+		{slave: dof{slave, 2}, masters: []dof{θ}, c: []float64{1}},
+	}
+	return
+}
+
+// Собственные колебания модели: K·x = λ·M·x с учетом опор
+// и связей. Вектора содержат все степени свободы.
+func (m model) modes() (e []eigen, err error) {
+	K, M, err := m.assemble()
+	if err != nil {
+		return
+	}
+	return m.constraints().solve(K, M)
+}
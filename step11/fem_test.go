@@ -0,0 +1,205 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// консольная или шарнирно опертая балка из n элементов вдоль x
+func beamModel(n int, L float64, lumped, simple bool) model {
+	m := model{kind: planeFrame, lumped: lumped}
+	for i := 0; i <= n; i++ {
+		m.nodes = append(m.nodes, node{x: L * float64(i) / float64(n)})
+	}
+	for i := 0; i < n; i++ {
+		m.elements = append(m.elements, element{
+			kind: beamElement,
+			n1:   i,
+			n2:   i + 1,
+			E:    2e11,
+			A:    0.01,
+			I:    1e-6,
+			ρ:    7850,
+		})
+	}
+	if simple {
+		m.supports = []support{{0, 0}, {0, 1}, {n, 1}}
+	} else {
+		m.supports = []support{{0, 0}, {0, 1}, {0, 2}}
+	}
+	return m
+}
+
+func TestFEMBeam(t *testing.T) {
+	L := 2.0
+	ω := func(βL float64) float64 {
+		return βL * βL * math.Sqrt(2e11*1e-6/(7850*0.01*math.Pow(L, 4)))
+	}
+	tcs := []struct {
+		name   string
+		m      model
+		values []float64 // ω
+		tol    float64
+	}{
+		{
+			name:   "cantilever",
+			m:      beamModel(20, L, false, false),
+			values: []float64{ω(1.875104069), ω(4.694091133), ω(7.854757438)},
+			tol:    1e-4,
+		},
+		{
+			name:   "simply supported",
+			m:      beamModel(20, L, false, true),
+			values: []float64{ω(math.Pi), ω(2 * math.Pi), ω(3 * math.Pi)},
+			tol:    1e-4,
+		},
+		{
+			name:   "cantilever lumped",
+			m:      beamModel(20, L, true, false),
+			values: []float64{ω(1.875104069), ω(4.694091133), ω(7.854757438)},
+			tol:    1e-2,
+		},
+		{
+			name: "rotated cantilever",
+			m: func() model {
+				m := beamModel(20, L, false, false)
+				for i := range m.nodes {
+					x := m.nodes[i].x
+					m.nodes[i].x, m.nodes[i].y = x*0.6, x*0.8
+				}
+				return m
+			}(),
+			values: []float64{ω(1.875104069), ω(4.694091133), ω(7.854757438)},
+			tol:    1e-4,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := tc.m.modes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, v := range tc.values {
+				w := math.Sqrt(e[i].𝜦)
+				if math.Abs(w-v) > tc.tol*v {
+					t.Errorf("mode %d: ω = %.8e != %.8e, error %.2e", i, w, v, math.Abs(w-v)/v)
+				}
+			}
+			if len(e[0].𝑿) != len(tc.m.nodes)*3 {
+				t.Errorf("size of eigenvector: %d", len(e[0].𝑿))
+			}
+		})
+	}
+}
+
+func TestFEM(t *testing.T) {
+	t.Run("springs", func(t *testing.T) {
+		// земля - k - m - k - m
+		k, mass := 1000.0, 2.0
+		m := model{
+			kind:  axialModel,
+			nodes: []node{{x: 0}, {x: 1}, {x: 2}},
+			elements: []element{
+				{kind: springElement, n1: 0, n2: 1, k: k},
+				{kind: springElement, n1: 1, n2: 2, k: k},
+			},
+			supports: []support{{0, 0}},
+			masses:   []pointMass{{1, mass}, {2, mass}},
+		}
+		e, err := m.modes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, sign := range []float64{-1, 1} {
+			l := k / mass * (3 + sign*math.Sqrt(5)) / 2
+			if math.Abs(e[i].𝜦-l) > 1e-10*l {
+				t.Errorf("mode %d: %.14e != %.14e", i, e[i].𝜦, l)
+			}
+		}
+		if e[0].𝑿[0] != 0 {
+			t.Errorf("support is moved: %v", e[0].𝑿)
+		}
+	})
+
+	t.Run("bar", func(t *testing.T) {
+		// продольные колебания консольного стержня
+		n, L := 50, 2.0
+		m := model{kind: axialModel, supports: []support{{0, 0}}}
+		for i := 0; i <= n; i++ {
+			m.nodes = append(m.nodes, node{x: L * float64(i) / float64(n)})
+		}
+		for i := 0; i < n; i++ {
+			m.elements = append(m.elements, element{
+				kind: trussElement, n1: i, n2: i + 1, E: 2e11, A: 0.01, ρ: 7850,
+			})
+		}
+		e, err := m.modes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ω := math.Pi / 2 * math.Sqrt(2e11/7850) / L
+		if w := math.Sqrt(e[0].𝜦); math.Abs(w-ω) > 1e-3*ω {
+			t.Errorf("ω = %.8e != %.8e", w, ω)
+		}
+	})
+
+	t.Run("truss", func(t *testing.T) {
+		// вершина на двух невесомых стержнях под 45°:
+		// жесткость в вершине k·I
+		for _, kind := range []modelKind{planeTruss, spaceTruss} {
+			EA, mass := 1000.0, 5.0
+			m := model{
+				kind:  kind,
+				nodes: []node{{x: -1}, {x: 0, y: 1}, {x: 1}},
+				elements: []element{
+					{kind: trussElement, n1: 0, n2: 1, E: EA, A: 1},
+					{kind: trussElement, n1: 2, n2: 1, E: EA, A: 1},
+				},
+				masses: []pointMass{{1, mass}},
+			}
+			for _, nd := range []int{0, 2} {
+				for d := 0; d < m.dofs(); d++ {
+					m.supports = append(m.supports, support{nd, d})
+				}
+			}
+			if kind == spaceTruss {
+				m.supports = append(m.supports, support{1, 2})
+			}
+			e, err := m.modes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			l := EA / math.Sqrt(2) / mass
+			if len(e) != 2 || math.Abs(e[0].𝜦-l) > 1e-10*l || math.Abs(e[1].𝜦-l) > 1e-10*l {
+				t.Errorf("%d: %v != %v", kind, e, l)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, m := range []model{
+			{},
+			{kind: axialModel, nodes: []node{{x: 0}, {x: 1}},
+				elements: []element{{kind: springElement, n1: 0, n2: 0, k: 1}}},
+			{kind: axialModel, nodes: []node{{x: 0}, {x: 0}},
+				elements: []element{{kind: springElement, n1: 0, n2: 1, k: 1}}},
+			{kind: axialModel, nodes: []node{{x: 0}, {y: 1}},
+				elements: []element{{kind: springElement, n1: 0, n2: 1, k: 1}}},
+			{kind: planeTruss, nodes: []node{{x: 0}, {x: 1}},
+				elements: []element{{kind: beamElement, n1: 0, n2: 1}}},
+			{kind: axialModel, nodes: []node{{x: 0}, {x: 1}},
+				elements: []element{{kind: springElement, n1: 0, n2: 1}}},
+			{kind: axialModel, nodes: []node{{x: 0}, {x: 1}},
+				elements: []element{{kind: springElement, n1: 0, n2: 1, k: 1}},
+				supports: []support{{0, 0}, {1, 0}}},
+			{kind: axialModel, nodes: []node{{x: 0}, {x: 1}},
+				supports: []support{{0, 1}}},
+			{kind: axialModel, nodes: []node{{x: 0}, {x: 1}},
+				masses: []pointMass{{2, 1}}},
+		} {
+			if _, err := m.modes(); err == nil {
+				t.Errorf("error is not found: %v", m)
+			}
+		}
+	})
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/cmplx"
+	"strconv"
+)
+
+// Частотные характеристики по формам колебаний
+//
+// Податливость при гармонической силе в степени свободы j
+// для форм, нормированных по массе:
+//
+//	H(i,j,ω) = Σ φ(i)·φ(j) / (ωr² - ω² + i·2·ζr·ωr·ω) + R(i,j)
+//
+// Остаточная податливость отброшенных высших форм считается
+// статической:
+//
+//	R = K⁻¹ - Σ φ·φᵀ / ωr²
+//
+// Подвижность равна i·ω·H, ускоряемость -ω²·H. Для проверки
+// H находится прямым решением (K - ω²·M + i·ω·C)·x = e(j).
+
+// вид частотной характеристики
+type frfKind int
+
+const (
+	receptance frfKind = iota
+	mobility
+	accelerance
+)
+
+func (k frfKind) String() string {
+	switch k {
+	case receptance:
+		return "receptance"
+	case mobility:
+		return "mobility"
+	case accelerance:
+		return "accelerance"
+	}
+	return fmt.Sprintf("frfKind(%d)", int(k))
+}
+
+// пара степеней свободы: отклик i, сила j
+type frfPair struct {
+	i, j int
+}
+
+// таблица частотных характеристик
+type frfTable struct {
+	kind frfKind
+
+	// круговые частоты
+	ω []float64
+
+	pairs []frfPair
+
+	// H[частота][пара]
+	H [][]complex128
+}
+
+// Коэффициенты демпфирования форм по Рэлею C = α·M + β·K:
+//
+//	ζ = α/(2·ω) + β·ω/2
+func rayleighRatios(modes []eigen, α, β float64) (ζ []float64) {
+	for _, m := range modes {
+		ω := math.Sqrt(math.Max(m.𝜦, 0.0))
+		var z float64
+		if ω > 0.0 {
+			z = α/(2.0*ω) + β*ω/2.0
+		}
+		ζ = append(ζ, z)
+	}
+	return
+}
+
+// множитель перехода от податливости к виду kind
+func (k frfKind) factor(ω float64) complex128 {
+	switch k {
+	case mobility:
+		return complex(0, ω)
+	case accelerance:
+		return complex(-ω*ω, 0)
+	}
+	return 1
+}
+
+func checkPairs(pairs []frfPair, n int) error {
+	if len(pairs) == 0 {
+		return fmt.Errorf("pairs of dofs are not found")
+	}
+	for _, p := range pairs {
+		if p.i < 0 || n <= p.i || p.j < 0 || n <= p.j {
+			return fmt.Errorf("pair %v is outside of model", p)
+		}
+	}
+	return nil
+}
+
+// Частотные характеристики по формам modes с коэффициентами
+// демпфирования ζ. При K != nil добавляется остаточная
+// податливость отброшенных форм.
+func modalFRF(modes []eigen, ζ []float64, K [][]float64, kind frfKind,
+	pairs []frfPair, ω []float64) (t frfTable, err error) {
+	if len(modes) == 0 {
+		err = fmt.Errorf("modes are not found")
+		return
+	}
+	if len(ζ) != len(modes) {
+		err = fmt.Errorf("amount of damping ratios %d is not same as modes %d", len(ζ), len(modes))
+		return
+	}
+	if kind < receptance || accelerance < kind {
+		err = fmt.Errorf("%v is not supported", kind)
+		return
+	}
+	n := len(modes[0].𝑿)
+	if err = checkPairs(pairs, n); err != nil {
+		return
+	}
+	for i, m := range modes {
+		if len(m.𝑿) != n {
+			err = fmt.Errorf("size of mode %d is %d, not %d", i, len(m.𝑿), n)
+			return
+		}
+		if m.𝜦 < 0.0 {
+			err = fmt.Errorf("eigenvalue of mode %d is negative: %.5e", i, m.𝜦)
+			return
+		}
+		if ζ[i] < 0.0 {
+			err = fmt.Errorf("damping ratio of mode %d is negative: %.5f", i, ζ[i])
+			return
+		}
+	}
+
+	// остаточная податливость
+	R := make([]float64, len(pairs))
+	if K != nil {
+		if len(K) != n {
+			err = fmt.Errorf("size of stiffness %d is not same as modes %d", len(K), n)
+			return
+		}
+		var f lu
+		if f, err = luFactorize(K, 0.0); err != nil {
+			err = fmt.Errorf("residual flexibility: %v", err)
+			return
+		}
+		e := make([]float64, n)
+		for p, pair := range pairs {
+			e[pair.j] = 1.0
+			R[p] = f.solve(e)[pair.i]
+			e[pair.j] = 0.0
+			for _, m := range modes {
+				if m.𝜦 == 0.0 {
+					err = fmt.Errorf("residual flexibility is not defined for rigid body modes")
+					return
+				}
+				R[p] -= m.𝑿[pair.i] * m.𝑿[pair.j] / m.𝜦
+			}
+		}
+	}
+
+	t = frfTable{kind: kind, ω: append([]float64{}, ω...), pairs: append([]frfPair{}, pairs...)}
+	for _, w := range ω {
+		row := make([]complex128, len(pairs))
+		for p, pair := range pairs {
+			H := complex(R[p], 0)
+			for r, m := range modes {
+				ωr := math.Sqrt(m.𝜦)
+				H += complex(m.𝑿[pair.i]*m.𝑿[pair.j], 0) /
+					complex(m.𝜦-w*w, 2.0*ζ[r]*ωr*w)
+			}
+			row[p] = H * kind.factor(w)
+		}
+		t.H = append(t.H, row)
+	}
+	return
+}
+
+// Частотные характеристики прямым решением
+// (K - ω²·M + i·ω·C)·x = e(j). Комплексная система решается
+// в вещественной форме:
+//
+//	[A  -B] [Re x]   [e]
+//	[B   A] [Im x] = [0], A = K - ω²·M, B = ω·C
+func directFRF(K, C, M [][]float64, kind frfKind, pairs []frfPair, ω []float64) (t frfTable, err error) {
+	n := len(K)
+	if len(M) != n || len(C) != n {
+		err = fmt.Errorf("size of matrices is not same")
+		return
+	}
+	if kind < receptance || accelerance < kind {
+		err = fmt.Errorf("%v is not supported", kind)
+		return
+	}
+	if err = checkPairs(pairs, n); err != nil {
+		return
+	}
+	t = frfTable{kind: kind, ω: append([]float64{}, ω...), pairs: append([]frfPair{}, pairs...)}
+	A := zeros(2 * n)
+	e := make([]float64, 2*n)
+	for _, w := range ω {
+		for a := 0; a < n; a++ {
+			for b := 0; b < n; b++ {
+				A[a][b] = K[a][b] - w*w*M[a][b]
+				A[n+a][n+b] = A[a][b]
+				A[a][n+b] = -w * C[a][b]
+				A[n+a][b] = w * C[a][b]
+			}
+		}
+		var f lu
+		if f, err = luFactorize(A, 0.0); err != nil {
+			err = fmt.Errorf("ω = %.5e: %v", w, err)
+			return
+		}
+		row := make([]complex128, len(pairs))
+		for p, pair := range pairs {
+			e[pair.j] = 1.0
+			x := f.solve(e)
+			e[pair.j] = 0.0
+			row[p] = complex(x[pair.i], x[n+pair.i]) * kind.factor(w)
+		}
+		t.H = append(t.H, row)
+	}
+	return
+}
+
+// Запись таблицы в CSV: частота в Гц, круговая частота и для каждой
+// пары вещественная и мнимая части, модуль и фаза в градусах
+func (t frfTable) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"f", "omega"}
+	for _, p := range t.pairs {
+		name := fmt.Sprintf("H(%d,%d)", p.i, p.j)
+		header = append(header, name+" re", name+" im", name+" abs", name+" phase")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	format := func(v float64) string {
+		return strconv.FormatFloat(v, 'e', 14, 64)
+	}
+	for k, ω := range t.ω {
+		record := []string{format(ω / (2.0 * math.Pi)), format(ω)}
+		for _, H := range t.H[k] {
+			record = append(record, format(real(H)), format(imag(H)),
+				format(cmplx.Abs(H)), format(cmplx.Phase(H)*180.0/math.Pi))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestFRF(t *testing.T) {
+	K, M := chain(5, 1000, 2, true)
+	modes, err := generalized(K, M)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs := []frfPair{{0, 0}, {4, 0}, {2, 3}}
+	ω := []float64{0, 3, 7.5, 15, 22.3, 40, 60}
+
+	compare := func(t *testing.T, a, b frfTable, tol float64) {
+		t.Helper()
+		for k := range a.ω {
+			for p := range a.pairs {
+				diff := cmplx.Abs(a.H[k][p] - b.H[k][p])
+				if diff > tol*cmplx.Abs(b.H[k][p]) {
+					t.Errorf("ω = %v, pair %v: %v != %v", a.ω[k], a.pairs[p], a.H[k][p], b.H[k][p])
+				}
+			}
+		}
+	}
+
+	t.Run("rayleigh", func(t *testing.T) {
+		α, β := 0.5, 1e-3
+		C := zeros(len(K))
+		for i := range C {
+			for j := range C[i] {
+				C[i][j] = α*M[i][j] + β*K[i][j]
+			}
+		}
+		for _, kind := range []frfKind{receptance, mobility, accelerance} {
+			m, err := modalFRF(modes, rayleighRatios(modes, α, β), nil, kind, pairs, ω)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d, err := directFRF(K, C, M, kind, pairs, ω)
+			if err != nil {
+				t.Fatal(err)
+			}
+			compare(t, m, d, 1e-10)
+		}
+	})
+
+	t.Run("modal damping", func(t *testing.T) {
+		ζ := []float64{0.02, 0.03, 0.05, 0.05, 0.1}
+		m, err := modalFRF(modes, ζ, nil, receptance, pairs, ω)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := directFRF(K, modalDamping(M, modes, ζ), M, receptance, pairs, ω)
+		if err != nil {
+			t.Fatal(err)
+		}
+		compare(t, m, d, 1e-10)
+
+		v, err := modalFRF(modes, ζ, nil, mobility, pairs, ω)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k := range ω {
+			for p := range pairs {
+				if cmplx.Abs(v.H[k][p]-complex(0, ω[k])*m.H[k][p]) > 1e-15 {
+					t.Errorf("mobility is not i·ω·H: %v", v.H[k][p])
+				}
+			}
+		}
+	})
+
+	t.Run("residual flexibility", func(t *testing.T) {
+		ζ := []float64{0.02, 0.02}
+		C := modalDamping(M, modes[:2], ζ)
+		low := []float64{0, 2, 5}
+		d, err := directFRF(K, C, M, receptance, pairs, low)
+		if err != nil {
+			t.Fatal(err)
+		}
+		truncated, err := modalFRF(modes[:2], ζ, nil, receptance, pairs, low)
+		if err != nil {
+			t.Fatal(err)
+		}
+		residual, err := modalFRF(modes[:2], ζ, K, receptance, pairs, low)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// при ω = 0 податливость точная
+		for p := range pairs {
+			if cmplx.Abs(residual.H[0][p]-d.H[0][p]) > 1e-10*cmplx.Abs(d.H[0][p]) {
+				t.Errorf("static pair %v: %v != %v", pairs[p], residual.H[0][p], d.H[0][p])
+			}
+		}
+		for k := range low {
+			for p := range pairs {
+				a := cmplx.Abs(truncated.H[k][p] - d.H[k][p])
+				b := cmplx.Abs(residual.H[k][p] - d.H[k][p])
+				if b > a {
+					t.Errorf("ω = %v, pair %v: residual error %v > %v", low[k], pairs[p], b, a)
+				}
+			}
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		m, err := modalFRF(modes, rayleighRatios(modes, 0.5, 1e-3), nil, accelerance, pairs, ω)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := m.writeCSV(&buf); err != nil {
+			t.Fatal(err)
+		}
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != len(ω)+1 {
+			t.Fatalf("amount of records: %d", len(records))
+		}
+		for _, r := range records {
+			if len(r) != 2+4*len(pairs) {
+				t.Fatalf("amount of columns: %d", len(r))
+			}
+		}
+		if records[0][2] != "H(0,0) re" {
+			t.Errorf("header: %v", records[0])
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		ζ := make([]float64, len(modes))
+		if _, err := modalFRF(nil, nil, nil, receptance, pairs, ω); err == nil {
+			t.Errorf("modes: error is not found")
+		}
+		if _, err := modalFRF(modes, ζ[:1], nil, receptance, pairs, ω); err == nil {
+			t.Errorf("damping: error is not found")
+		}
+		if _, err := modalFRF(modes, ζ, nil, frfKind(7), pairs, ω); err == nil {
+			t.Errorf("kind: error is not found")
+		}
+		if _, err := modalFRF(modes, ζ, nil, receptance, []frfPair{{0, 5}}, ω); err == nil {
+			t.Errorf("pair: error is not found")
+		}
+		if _, err := modalFRF(modes, ζ, nil, receptance, nil, ω); err == nil {
+			t.Errorf("pairs: error is not found")
+		}
+		// резонанс без демпфирования
+		if _, err := directFRF(K, zeros(5), M, receptance, pairs,
+			[]float64{math.Sqrt(modes[0].𝜦)}); err == nil {
+			t.Errorf("resonance: error is not found")
+		}
+	})
+}
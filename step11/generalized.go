@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Обобщенная задача на собственные значения
+//
+//	K·x = λ·M·x
+//
+// Для симметричной положительно определенной M = L·Lᵀ
+// задача сводится к стандартной симметричной:
+//
+//	C·y = λ·y, C = L⁻¹·K·L⁻ᵀ, x = L⁻ᵀ·y
+//
+// Вектора x нормированы по массе: xᵀ·M·x = 1.
+
+// Разложение Холецкого M = L·Lᵀ
+func cholesky(M [][]float64) (L [][]float64, err error) {
+	n := len(M)
+	L = make([][]float64, n)
+	for i := range L {
+		L[i] = make([]float64, n)
+	}
+	for j := 0; j < n; j++ {
+		d := M[j][j]
+		for k := 0; k < j; k++ {
+			d -= L[j][k] * L[j][k]
+		}
+		if d <= 𝛆*math.Abs(M[j][j]) || d <= 0.0 {
+			err = fmt.Errorf("matrix is not positive definite in column %d", j)
+			return
+		}
+		L[j][j] = math.Sqrt(d)
+		for i := j + 1; i < n; i++ {
+			s := M[i][j]
+			for k := 0; k < j; k++ {
+				s -= L[i][k] * L[j][k]
+			}
+			L[i][j] = s / L[j][j]
+		}
+	}
+	return
+}
+
+// решение L·x = b
+func lowerSolve(L [][]float64, b []float64) (x []float64) {
+	x = make([]float64, len(b))
+	for i := range x {
+		x[i] = b[i]
+		for k := 0; k < i; k++ {
+			x[i] -= L[i][k] * x[k]
+		}
+		x[i] /= L[i][i]
+	}
+	return
+}
+
+// решение Lᵀ·x = b
+func upperSolve(L [][]float64, b []float64) (x []float64) {
+	n := len(b)
+	x = make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		x[i] = b[i]
+		for k := i + 1; k < n; k++ {
+			x[i] -= L[k][i] * x[k]
+		}
+		x[i] /= L[i][i]
+	}
+	return
+}
+
+// Собственные пары K·x = λ·M·x по возрастанию λ
+func generalized(K, M [][]float64) (e []eigen, err error) {
+	if err = checkInput(K); err != nil {
+		return
+	}
+	if len(M) != len(K) {
+		err = fmt.Errorf("size of mass matrix %d is not same as stiffness %d",
+			len(M), len(K))
+		return
+	}
+	if err = checkSymmetric(K); err != nil {
+		return
+	}
+	if err = checkSymmetric(M); err != nil {
+		return
+	}
+	n := len(K)
+
+	L, err := cholesky(M)
+	if err != nil {
+		err = fmt.Errorf("mass matrix: %v", err)
+		return
+	}
+
+	// C = L⁻¹·K·L⁻ᵀ по столбцам: сначала L⁻¹·K, затем (L⁻¹·(L⁻¹·K)ᵀ)ᵀ
+	B := make([][]float64, n) // B[col] = L⁻¹·K[:,col]
+	col := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			col[i] = K[i][j]
+		}
+		B[j] = lowerSolve(L, col)
+	}
+	C := make([][]float64, n)
+	for i := range C {
+		C[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		// строка i матрицы L⁻¹·K
+		for j := 0; j < n; j++ {
+			col[j] = B[j][i]
+		}
+		row := lowerSolve(L, col)
+		for j := 0; j < n; j++ {
+			C[i][j] = row[j]
+		}
+	}
+	// симметризация ошибок округления
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			C[i][j] = (C[i][j] + C[j][i]) / 2.0
+			C[j][i] = C[i][j]
+		}
+	}
+
+	if e, err = jacobi(C, cyclicSweep); err != nil {
+		return
+	}
+	nz := normalizer{mode: massNorm, M: M, sign: true}
+	for i := range e {
+		e[i].𝑿 = upperSolve(L, e[i].𝑿)
+		if err = nz.vector(e[i].𝑿); err != nil {
+			return
+		}
+	}
+	sort.SliceStable(e, func(i, j int) bool {
+		return e[i].𝜦 < e[j].𝜦
+	})
+	return
+}
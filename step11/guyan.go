@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Статическая конденсация Гайана
+//
+// Степени свободы делятся на главные m и подчиненные s.
+// Силы инерции подчиненных степеней свободы не учитываются:
+//
+//	Kss·xs + Ksm·xm = 0, xs = -Kss⁻¹·Ksm·xm
+//	x = T·xm, T = [I; -Kss⁻¹·Ksm]
+//	Kr = Tᵀ·K·T, Mr = Tᵀ·M·T
+//
+// Погрешность тем меньше, чем больше отношения Kii/Mii
+// подчиненных степеней свободы, поэтому при автоматическом
+// выборе главными становятся степени свободы с наименьшим Kii/Mii.
+
+// результат конденсации
+type guyan struct {
+	// главные степени свободы
+	masters []int
+
+	// матрица преобразования x = T·xm
+	T [][]float64
+
+	// собственные пары с векторами полного размера
+	e []eigen
+
+	// относительная погрешность λ низших форм по полному решению,
+	// для форм жесткого смещения - по наибольшему |λ| полного решения
+	errors []float64
+
+	// MAC форм по полному решению, 1 - совпадение
+	mac []float64
+}
+
+// Автоматический выбор count главных степеней свободы
+// по наименьшему отношению Kii/Mii
+func guyanMasters(K, M [][]float64, count int) (masters []int, err error) {
+	n := len(K)
+	if count <= 0 || count > n {
+		err = fmt.Errorf("amount of masters %d is outside of [1, %d]", count, n)
+		return
+	}
+	ratio := make([]float64, n)
+	index := make([]int, n)
+	for i := range ratio {
+		index[i] = i
+		ratio[i] = math.Inf(1)
+		if M[i][i] > 0.0 {
+			ratio[i] = K[i][i] / M[i][i]
+		}
+	}
+	sort.SliceStable(index, func(a, b int) bool {
+		return ratio[index[a]] < ratio[index[b]]
+	})
+	masters = append(masters, index[:count]...)
+	sort.Ints(masters)
+	return
+}
+
+// Конденсация на главные степени свободы и решение
+// K·x = λ·M·x. Для check > 0 находится погрешность check
+// низших форм по полному решению.
+func guyanReduction(K, M [][]float64, masters []int, check int) (g guyan, err error) {
+	n := len(K)
+	if len(M) != n {
+		err = fmt.Errorf("size of mass matrix %d is not same as stiffness %d", len(M), n)
+		return
+	}
+	isMaster := make([]bool, n)
+	for _, m := range masters {
+		if m < 0 || n <= m || isMaster[m] {
+			err = fmt.Errorf("master %d is not valid", m)
+			return
+		}
+		isMaster[m] = true
+	}
+	if len(masters) == 0 {
+		err = fmt.Errorf("masters are not found")
+		return
+	}
+	g.masters = append([]int{}, masters...)
+	sort.Ints(g.masters)
+
+	var slaves []int
+	for i := 0; i < n; i++ {
+		if !isMaster[i] {
+			slaves = append(slaves, i)
+		}
+	}
+
+	// T = [I; -Kss⁻¹·Ksm]
+	g.T = make([][]float64, n)
+	for i := range g.T {
+		g.T[i] = make([]float64, len(g.masters))
+	}
+	for j, m := range g.masters {
+		g.T[m][j] = 1.0
+	}
+	if len(slaves) > 0 {
+		Kss := make([][]float64, len(slaves))
+		for a := range slaves {
+			Kss[a] = make([]float64, len(slaves))
+			for b := range slaves {
+				Kss[a][b] = K[slaves[a]][slaves[b]]
+			}
+		}
+		var f lu
+		if f, err = luFactorize(Kss, 0.0); err != nil {
+			err = fmt.Errorf("stiffness of slaves: %v", err)
+			return
+		}
+		b := make([]float64, len(slaves))
+		for j, m := range g.masters {
+			for a := range slaves {
+				b[a] = -K[slaves[a]][m]
+			}
+			xs := f.solve(b)
+			for a, s := range slaves {
+				g.T[s][j] = xs[a]
+			}
+		}
+	}
+
+	if g.e, err = generalized(transformed(K, g.T), transformed(M, g.T)); err != nil {
+		return
+	}
+	for i := range g.e {
+		g.e[i].𝑿 = expandWith(g.T, g.e[i].𝑿)
+	}
+
+	if check <= 0 {
+		return
+	}
+	if check > len(g.e) {
+		check = len(g.e)
+	}
+	full, err := generalized(K, M)
+	if err != nil {
+		return
+	}
+	// λ ≈ 0 форм жесткого смещения находится с абсолютной
+	// погрешностью, поэтому деление на него дает Inf или NaN
+	var λmax float64
+	for i := range full {
+		λmax = math.Max(λmax, math.Abs(full[i].𝜦))
+	}
+	for i := 0; i < check; i++ {
+		scale := math.Abs(full[i].𝜦)
+		if scale <= math.Sqrt(𝛆)*λmax {
+			scale = λmax
+		}
+		if scale == 0.0 {
+			err = fmt.Errorf("all eigenvalues of full solution is zeros")
+			return
+		}
+		g.errors = append(g.errors, math.Abs(g.e[i].𝜦-full[i].𝜦)/scale)
+		g.mac = append(g.mac, mac(g.e[i].𝑿, full[i].𝑿))
+	}
+	if output {
+		for i := range g.errors {
+			fmt.Printf("guyan mode %d: λ = %.14e, error = %.5e, MAC = %.5f\n",
+				i, g.e[i].𝜦, g.errors[i], g.mac[i])
+		}
+	}
+	return
+}
+
+// Modal Assurance Criterion: (xᵀ·y)² / ((xᵀ·x)·(yᵀ·y))
+func mac(x, y []float64) float64 {
+	c := cosine(x, y)
+	return c * c
+}
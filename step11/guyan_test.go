@@ -0,0 +1,113 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGuyan(t *testing.T) {
+	// консольная балка без закрепленных степеней свободы
+	m := beamModel(10, 2.0, false, false)
+	K, M, err := m.assemble()
+	if err != nil {
+		t.Fatal(err)
+	}
+	T, err := m.constraints().transform()
+	if err != nil {
+		t.Fatal(err)
+	}
+	K, M = transformed(K, T), transformed(M, T)
+	full, err := generalized(K, M)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("all masters", func(t *testing.T) {
+		masters := make([]int, len(K))
+		for i := range masters {
+			masters[i] = i
+		}
+		g, err := guyanReduction(K, M, masters, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range g.errors {
+			if g.errors[i] > 1e-12 || math.Abs(g.mac[i]-1) > 1e-12 {
+				t.Errorf("mode %d: error %v, MAC %v", i, g.errors[i], g.mac[i])
+			}
+		}
+	})
+
+	t.Run("automatic masters", func(t *testing.T) {
+		var last float64
+		for _, count := range []int{5, 10, 15} {
+			masters, err := guyanMasters(K, M, count)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g, err := guyanReduction(K, M, masters, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("masters %2d: errors %.3e, MAC %.6f", count, g.errors, g.mac)
+			if len(g.e) != count || len(g.e[0].𝑿) != len(K) {
+				t.Fatalf("size: %d, %d", len(g.e), len(g.e[0].𝑿))
+			}
+			if g.errors[0] > 1e-2 || g.mac[0] < 0.999 {
+				t.Errorf("first mode: error %v, MAC %v", g.errors[0], g.mac[0])
+			}
+			// Гайан дает оценку сверху
+			for i := range g.e {
+				if g.e[i].𝜦 < full[i].𝜦*(1-1e-10) {
+					t.Errorf("mode %d: %.14e < %.14e", i, g.e[i].𝜦, full[i].𝜦)
+				}
+			}
+			if count > 5 && g.errors[2] > last {
+				t.Errorf("error is not decreased: %v > %v", g.errors[2], last)
+			}
+			last = g.errors[2]
+		}
+	})
+
+	t.Run("rigid modes", func(t *testing.T) {
+		// свободная балка: три формы жесткого смещения с λ ≈ 0
+		m := beamModel(10, 2.0, false, false)
+		m.supports = nil
+		K, M, err := m.assemble()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// все степени свободы узлов 0, 5, 10, иначе подчиненные
+		// степени свободы могут смещаться как жесткое целое
+		var masters []int
+		for _, node := range []int{0, 5, 10} {
+			masters = append(masters, 3*node, 3*node+1, 3*node+2)
+		}
+		g, err := guyanReduction(K, M, masters, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("errors %.3e", g.errors)
+		for i := range g.errors {
+			if math.IsNaN(g.errors[i]) || math.IsInf(g.errors[i], 0) {
+				t.Errorf("mode %d: error %v", i, g.errors[i])
+			}
+		}
+		for i := 0; i < 3; i++ {
+			if g.errors[i] > 1e-8 {
+				t.Errorf("rigid mode %d: error %v", i, g.errors[i])
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, masters := range [][]int{{}, {-1}, {0, 0}, {len(K)}} {
+			if _, err := guyanReduction(K, M, masters, 0); err == nil {
+				t.Errorf("%v: error is not found", masters)
+			}
+		}
+		if _, err := guyanMasters(K, M, 0); err == nil {
+			t.Errorf("error is not found")
+		}
+	})
+}
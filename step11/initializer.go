@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Стратегии выбора начального вектора
+//
+// Начальный вектор должен содержать составляющую искомого
+// собственного вектора, иначе итерации сходятся к другой
+// собственной паре. Для несимметричной матрицы составляющая
+// определяется левым собственным вектором v:
+//
+//	x = Σ cᵢ·uᵢ, c₁ = (v₁, x) / (v₁, u₁)
+
+// стратегия начального вектора
+type initializer interface {
+	start(x []float64) error
+}
+
+// Использование стратегии для глобальной функции initialize.
+// При ошибке стратегии или нулевом векторе используется
+// случайный вектор.
+//
+//	initialize = startWith(onesStart{})
+func startWith(s initializer) func([]float64) {
+	return func(x []float64) {
+		err := s.start(x)
+		if err == nil {
+			err = checkStart(nil, x)
+		}
+		if err != nil {
+			if output {
+				fmt.Printf("start vector: %v. random vector is used\n", err)
+			}
+			random(x)
+		}
+	}
+}
+
+// случайный вектор с заданным зерном
+type randomStart struct {
+	seed int64
+}
+
+func (s randomStart) start(x []float64) error {
+	r := rand.New(rand.NewSource(s.seed))
+	for i := range x {
+		x[i] = r.Float64() - 0.5
+	}
+	return nil
+}
+
+// единичный вектор
+type onesStart struct{}
+
+func (onesStart) start(x []float64) error {
+	for i := range x {
+		x[i] = 1.0
+	}
+	return nil
+}
+
+// Вектор с весами 1/A[i][i]. Степени свободы с малой
+// жесткостью получают больший вес, что приближает вектор
+// к низшим формам.
+type diagonalStart struct {
+	A [][]float64
+}
+
+func (s diagonalStart) start(x []float64) error {
+	if len(s.A) != len(x) {
+		return fmt.Errorf("size of matrix %d is not same as vector %d", len(s.A), len(x))
+	}
+	for i := range x {
+		x[i] = 1.0
+		if d := math.Abs(s.A[i][i]); d != 0.0 {
+			x[i] = 1.0 / d
+		}
+	}
+	return nil
+}
+
+// Статический прогиб от единичной нагрузки x = K⁻¹·M·1.
+// Для M = nil используется единичная матрица масс.
+type staticStart struct {
+	K, M [][]float64
+}
+
+func (s staticStart) start(x []float64) error {
+	n := len(s.K)
+	if n != len(x) {
+		return fmt.Errorf("size of matrix %d is not same as vector %d", n, len(x))
+	}
+	load := make([]float64, n)
+	ones := make([]float64, n)
+	onesStart{}.start(ones)
+	if s.M == nil {
+		copy(load, ones)
+	} else {
+		dense(s.M).mul(load, ones)
+	}
+	f, err := luFactorize(s.K, 0.0)
+	if err != nil {
+		return fmt.Errorf("static deflection: %v", err)
+	}
+	copy(x, f.solve(load))
+	return nil
+}
+
+// вектор пользователя
+type vectorStart struct {
+	x []float64
+}
+
+func (s vectorStart) start(x []float64) error {
+	if len(s.x) != len(x) {
+		return fmt.Errorf("size of start vector %d is not same as %d", len(s.x), len(x))
+	}
+	copy(x, s.x)
+	return nil
+}
+
+// Теплый старт по предыдущему решению, например для
+// немного измененной матрицы
+type warmStart struct {
+	e eigen
+}
+
+func (s warmStart) start(x []float64) error {
+	if len(s.e.𝑿) != len(x) {
+		return fmt.Errorf("size of previous eigenvector %d is not same as %d",
+			len(s.e.𝑿), len(x))
+	}
+	copy(x, s.e.𝑿)
+	return nil
+}
+
+// проверять начальный вектор решателей по искомому собственному
+// вектору. Проверка требует дополнительного решения степенным
+// методом, поэтому по умолчанию проверяется только, что вектор
+// ненулевой.
+var startCheck bool = false
+
+// Проверка начального вектора решателя для итерируемого
+// оператора A
+func checkSolverStart(A operator, x []float64) error {
+	if !startCheck {
+		A = nil
+	}
+	return checkStart(A, x)
+}
+
+// начальный вектор решателя для итерируемого оператора A
+func startFor(A operator, x []float64) error {
+	initialize(x)
+	return checkSolverStart(A, x)
+}
+
+// Проверка, что начальный вектор не ортогонален искомому
+// собственному вектору. Искомым является доминирующий
+// собственный вектор итерируемого оператора A: для обратных
+// итераций это (A - σ·I)⁻¹, после исчерпывания - оператор
+// с исчерпыванием.
+// Левый собственный вектор находится по Aᵀ, если
+// оператор поддерживает умножение на транспонированную матрицу,
+// иначе матрица считается симметричной.
+// При A = nil проверяется только, что вектор ненулевой.
+func checkStart(A operator, x []float64) (err error) {
+	var zero = true
+	for i := range x {
+		if x[i] != 0.0 {
+			zero = false
+		}
+	}
+	if zero {
+		return fmt.Errorf("all values of start vector is zeros")
+	}
+	if A == nil {
+		return
+	}
+
+	mul := A.mul
+	if t, ok := A.(transposer); ok {
+		mul = t.mulT
+	}
+	v := make([]float64, A.size())
+	randomStart{seed: 1}.start(v)
+	if _, _, err = power(mul, v); err != nil {
+		return
+	}
+	if c := cosine(v, x); math.Abs(c) < math.Sqrt(𝛆) {
+		return fmt.Errorf("start vector is orthogonal to the wanted eigenvector: cos = %.5e", c)
+	}
+	return
+}
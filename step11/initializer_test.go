@@ -0,0 +1,180 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInitializer(t *testing.T) {
+	K := [][]float64{
+		{2, -1, 0},
+		{-1, 2, -1},
+		{0, -1, 1},
+	}
+	M := [][]float64{
+		{2, 0, 0},
+		{0, 2, 0},
+		{0, 0, 1},
+	}
+
+	tcs := []struct {
+		name   string
+		s      initializer
+		expect []float64
+	}{
+		{name: "ones", s: onesStart{}, expect: []float64{1, 1, 1}},
+		{name: "diagonal", s: diagonalStart{A: K}, expect: []float64{0.5, 0.5, 1}},
+		// K·x = 1 : x = (3, 5, 6)
+		{name: "static", s: staticStart{K: K}, expect: []float64{3, 5, 6}},
+		// K·x = M·1 : x = (5, 8, 9)
+		{name: "static with mass", s: staticStart{K: K, M: M}, expect: []float64{5, 8, 9}},
+		{name: "vector", s: vectorStart{x: []float64{1, 2, 3}}, expect: []float64{1, 2, 3}},
+		{name: "warm", s: warmStart{e: eigen{𝑿: []float64{3, 2, 1}}}, expect: []float64{3, 2, 1}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			x := make([]float64, 3)
+			if err := tc.s.start(x); err != nil {
+				t.Fatal(err)
+			}
+			for i := range x {
+				if math.Abs(x[i]-tc.expect[i]) > 1e-14 {
+					t.Fatalf("%v != %v", x, tc.expect)
+				}
+			}
+		})
+	}
+
+	t.Run("random is seeded", func(t *testing.T) {
+		x, y := make([]float64, 5), make([]float64, 5)
+		randomStart{seed: 7}.start(x)
+		randomStart{seed: 7}.start(y)
+		for i := range x {
+			if x[i] != y[i] {
+				t.Fatalf("%v != %v", x, y)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		x := make([]float64, 2)
+		for _, s := range []initializer{
+			diagonalStart{A: K},
+			staticStart{K: [][]float64{{1, 1}, {1, 1}}},
+			vectorStart{x: []float64{1}},
+			warmStart{},
+		} {
+			if err := s.start(x); err == nil {
+				t.Errorf("%T: error is not found", s)
+			}
+		}
+	})
+
+	t.Run("pm with static start", func(t *testing.T) {
+		old := initialize
+		defer func() {
+			initialize = old
+		}()
+		// ошибка стратегии - случайный вектор
+		initialize = startWith(vectorStart{x: []float64{1}})
+		if _, err := pm(K); err != nil {
+			t.Fatal(err)
+		}
+		initialize = startWith(staticStart{K: K})
+		es, err := pm(K)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r := residual(K, es[0]); r > 1e-6 {
+			t.Errorf("residual: %v", r)
+		}
+	})
+}
+
+func TestCheckStart(t *testing.T) {
+	A := [][]float64{
+		{3, 0, 0},
+		{0, 2, 0},
+		{0, 0, 1},
+	}
+	if err := checkStart(dense(A), []float64{1, 1, 1}); err != nil {
+		t.Error(err)
+	}
+	for _, x := range [][]float64{
+		{0, 1, 1},
+		{0, 0, 0},
+	} {
+		if err := checkStart(dense(A), x); err == nil {
+			t.Errorf("%v: error is not found", x)
+		} else {
+			t.Log(err)
+		}
+	}
+
+	// несимметричная матрица: x ортогонален правому собственному
+	// вектору (1, 1), но не левому (1, 0)
+	B := [][]float64{
+		{2, 0},
+		{1, 1},
+	}
+	if err := checkStart(dense(B), []float64{1, -1}); err != nil {
+		t.Error(err)
+	}
+	// x ортогонален левому собственному вектору (1, 0)
+	if err := checkStart(dense(B), []float64{0, 1}); err == nil {
+		t.Errorf("error is not found")
+	}
+}
+
+func TestSolverStart(t *testing.T) {
+	old := initialize
+	defer func() {
+		initialize = old
+	}()
+	oldCheck := startCheck
+	defer func() {
+		startCheck = oldCheck
+	}()
+
+	A := [][]float64{
+		{3, 0, 0},
+		{0, 2, 0},
+		{0, 0, 1},
+	}
+
+	// нулевой вектор стратегии заменяется случайным
+	initialize = startWith(vectorStart{x: []float64{0, 0, 0}})
+	x := make([]float64, 3)
+	initialize(x)
+	if err := checkStart(nil, x); err != nil {
+		t.Fatal(err)
+	}
+
+	// вектор ортогонален собственному вектору λ = 3
+	initialize = func(x []float64) {
+		copy(x, []float64{0, 1, 1})
+	}
+	startCheck = false
+	if _, err := pm(A); err != nil {
+		t.Fatal(err)
+	}
+	startCheck = true
+	if _, err := pm(A); err == nil {
+		t.Errorf("pm: error is not found")
+	}
+
+	// Для обратных итераций искомый вектор - собственный вектор
+	// λ = 1, ближайшего к σ. Вектор (1, 1, 0) не ортогонален
+	// доминирующему вектору A, но ортогонален искомому.
+	initialize = func(x []float64) {
+		copy(x, []float64{1, 1, 0})
+	}
+	if _, err := exhSelect(A, selector{which: nearestTarget, σ: 0.9, nev: 1}); err == nil {
+		t.Errorf("shift-invert: error is not found")
+	} else {
+		t.Log(err)
+	}
+	if _, err := exhSelect(A, selector{which: largestMagnitude, nev: 1}); err != nil {
+		t.Errorf("largest: %v", err)
+	}
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Метод вращений Якоби для симметричных матриц
+//
+// Литература:
+// * Голуб Дж., Ван Лоун Ч. Матричные вычисления. Глава 8.4
+// * Brent R.P., Luk F.T. The solution of singular-value and symmetric
+//   eigenvalue problems on multiprocessor arrays. 1985
+
+// способ обхода внедиагональных элементов
+type jacobiSweep int
+
+const (
+	// циклический обход: вращение для каждого элемента
+	cyclicSweep jacobiSweep = iota
+
+	// пороговый обход: вращение только для элементов больше порога
+	thresholdSweep
+)
+
+// максимальное количество обходов
+var jacobiMaxSweep int = 100
+
+func jacobi(A [][]float64, sweep jacobiSweep) (e []eigen, err error) {
+	a, v, err := jacobiPrepare(A)
+	if err != nil {
+		return
+	}
+	n := len(a)
+
+	for iter := 0; ; iter++ {
+		off, norm := jacobiOff(a)
+
+		if output {
+			fmt.Printf("sweep: %2d\toff = %10.5e\n", iter, off)
+		}
+
+		if off <= 𝛆*norm {
+			break
+		}
+		if iter >= jacobiMaxSweep {
+			err = fmt.Errorf("Iteration limit")
+			return
+		}
+
+		// порог вращения
+		var threshold float64
+		if sweep == thresholdSweep {
+			threshold = off / float64(n)
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				if math.Abs(a[p][q]) <= threshold {
+					continue
+				}
+				c, s, ok := jacobiRotation(a, p, q)
+				if !ok {
+					continue
+				}
+				jacobiColumns(a, v, p, q, c, s)
+				jacobiRows(a, p, q, c, s)
+			}
+		}
+	}
+
+	e = jacobiResult(a, v)
+	return
+}
+
+// Параллельный вариант метода Якоби.
+// Порядок вращений round-robin (Brent-Luk): на каждом шаге обхода
+// пары индексов (p,q) не пересекаются, поэтому вращения независимы
+// и выполняются одновременно. Сначала вращаются столбцы всех пар,
+// затем строки.
+func jacobiParallel(A [][]float64) (e []eigen, err error) {
+	a, v, err := jacobiPrepare(A)
+	if err != nil {
+		return
+	}
+	n := len(a)
+
+	// для нечетного размера добавляем фиктивный индекс
+	m := n
+	if m%2 == 1 {
+		m++
+	}
+	players := make([]int, m)
+	for i := range players {
+		players[i] = i
+	}
+
+	type pair struct {
+		p, q int
+		c, s float64
+	}
+	pairs := make([]pair, 0, m/2)
+
+	workers := runtime.GOMAXPROCS(0)
+
+	// выполнение функции для всех пар на нескольких горутинах
+	parallel := func(f func(pr pair)) {
+		var wg sync.WaitGroup
+		size := (len(pairs) + workers - 1) / workers
+		for from := 0; from < len(pairs); from += size {
+			to := from + size
+			if to > len(pairs) {
+				to = len(pairs)
+			}
+			wg.Add(1)
+			go func(part []pair) {
+				defer wg.Done()
+				for _, pr := range part {
+					f(pr)
+				}
+			}(pairs[from:to])
+		}
+		wg.Wait()
+	}
+
+	for iter := 0; ; iter++ {
+		off, norm := jacobiOff(a)
+
+		if output {
+			fmt.Printf("sweep: %2d\toff = %10.5e\n", iter, off)
+		}
+
+		if off <= 𝛆*norm {
+			break
+		}
+		if iter >= jacobiMaxSweep {
+			err = fmt.Errorf("Iteration limit")
+			return
+		}
+
+		for round := 0; round < m-1; round++ {
+			// независимые пары текущего шага
+			pairs = pairs[:0]
+			for i := 0; i < m/2; i++ {
+				p, q := players[i], players[m-1-i]
+				if p > q {
+					p, q = q, p
+				}
+				if q >= n {
+					continue
+				}
+				c, s, ok := jacobiRotation(a, p, q)
+				if !ok {
+					continue
+				}
+				pairs = append(pairs, pair{p: p, q: q, c: c, s: s})
+			}
+
+			if len(pairs) > 0 {
+				parallel(func(pr pair) {
+					jacobiColumns(a, v, pr.p, pr.q, pr.c, pr.s)
+				})
+				parallel(func(pr pair) {
+					jacobiRows(a, pr.p, pr.q, pr.c, pr.s)
+				})
+			}
+
+			// сдвиг по кругу всех индексов кроме первого
+			last := players[m-1]
+			copy(players[2:], players[1:m-1])
+			players[1] = last
+		}
+	}
+
+	e = jacobiResult(a, v)
+	return
+}
+
+// копия входной матрицы и единичная матрица собственных векторов
+func jacobiPrepare(A [][]float64) (a, v [][]float64, err error) {
+	if err = checkInput(A); err != nil {
+		return
+	}
+	if err = checkSymmetric(A); err != nil {
+		return
+	}
+	n := len(A)
+	a = make([][]float64, n)
+	v = make([][]float64, n)
+	for i := 0; i < n; i++ {
+		a[i] = make([]float64, n)
+		copy(a[i], A[i])
+		v[i] = make([]float64, n)
+		v[i][i] = 1.0
+	}
+	return
+}
+
+// норма внедиагональных элементов и норма Фробениуса матрицы
+func jacobiOff(a [][]float64) (off, norm float64) {
+	for row := range a {
+		for col := range a[row] {
+			sq := a[row][col] * a[row][col]
+			norm += sq
+			if row != col {
+				off += sq
+			}
+		}
+	}
+	return math.Sqrt(off), math.Sqrt(norm)
+}
+
+// параметры вращения, обнуляющего элемент a[p][q]
+func jacobiRotation(a [][]float64, p, q int) (c, s float64, ok bool) {
+	if a[p][q] == 0.0 {
+		return
+	}
+	tau := (a[q][q] - a[p][p]) / (2.0 * a[p][q])
+	var t float64
+	if tau >= 0 {
+		t = 1.0 / (tau + math.Sqrt(1.0+tau*tau))
+	} else {
+		t = -1.0 / (-tau + math.Sqrt(1.0+tau*tau))
+	}
+	c = 1.0 / math.Sqrt(1.0+t*t)
+	s = t * c
+	return c, s, true
+}
+
+// A = A · J, V = V · J
+func jacobiColumns(a, v [][]float64, p, q int, c, s float64) {
+	for k := range a {
+		akp, akq := a[k][p], a[k][q]
+		a[k][p] = c*akp - s*akq
+		a[k][q] = s*akp + c*akq
+
+		vkp, vkq := v[k][p], v[k][q]
+		v[k][p] = c*vkp - s*vkq
+		v[k][q] = s*vkp + c*vkq
+	}
+}
+
+// A = Jᵀ · A
+func jacobiRows(a [][]float64, p, q int, c, s float64) {
+	for k := range a[p] {
+		apk, aqk := a[p][k], a[q][k]
+		a[p][k] = c*apk - s*aqk
+		a[q][k] = s*apk + c*aqk
+	}
+	// элемент равен нулю по построению вращения
+	a[p][q], a[q][p] = 0.0, 0.0
+}
+
+// собственные пары по убыванию модуля собственного значения
+func jacobiResult(a, v [][]float64) (e []eigen) {
+	n := len(a)
+	for col := 0; col < n; col++ {
+		x := make([]float64, n)
+		for row := 0; row < n; row++ {
+			x[row] = v[row][col]
+		}
+		oneMax(x, x)
+		e = append(e, eigen{𝑿: x, 𝜦: a[col][col]})
+	}
+	sort.SliceStable(e, func(i, j int) bool {
+		return math.Abs(e[i].𝜦) > math.Abs(e[j].𝜦)
+	})
+	return
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// симметричная матрица с произвольными значениями
+func randomSymmetric(n int, seed int64) (A [][]float64) {
+	r := rand.New(rand.NewSource(seed))
+	A = make([][]float64, n)
+	for i := range A {
+		A[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			A[i][j] = r.Float64()*2.0 - 1.0
+			A[j][i] = A[i][j]
+		}
+	}
+	return
+}
+
+// проверка собственных пар: A·x = λ·x
+func residual(A [][]float64, e eigen) (delta float64) {
+	var xMax float64
+	for i := range e.𝑿 {
+		xMax = math.Max(xMax, math.Abs(e.𝑿[i]))
+	}
+	for row := range A {
+		var sum float64
+		for col := range A[row] {
+			sum += A[row][col] * e.𝑿[col]
+		}
+		delta = math.Max(delta, math.Abs(sum-e.𝜦*e.𝑿[row])/xMax)
+	}
+	return
+}
+
+func TestJacobi(t *testing.T) {
+	solvers := []struct {
+		name  string
+		solve func([][]float64) ([]eigen, error)
+	}{
+		{
+			name: "cyclic",
+			solve: func(A [][]float64) ([]eigen, error) {
+				return jacobi(A, cyclicSweep)
+			},
+		},
+		{
+			name: "threshold",
+			solve: func(A [][]float64) ([]eigen, error) {
+				return jacobi(A, thresholdSweep)
+			},
+		},
+		{
+			name:  "parallel",
+			solve: jacobiParallel,
+		},
+	}
+
+	for _, s := range solvers {
+		t.Run(s.name, func(t *testing.T) {
+			t.Run("D", func(t *testing.T) {
+				e, err := s.solve([][]float64{
+					{17, -2, -2},
+					{-2, 14, -4},
+					{-2, -4, 14},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				PrintEigens(e)
+				expect := []float64{18, 18, 9}
+				for i := range e {
+					if math.Abs(e[i].𝜦-expect[i]) > 1e-12 {
+						t.Errorf("eigenvalue %d: %.14e != %.14e", i, e[i].𝜦, expect[i])
+					}
+				}
+			})
+			for _, n := range []int{1, 2, 5, 8, 21} {
+				t.Run(fmt.Sprintf("random %d", n), func(t *testing.T) {
+					A := randomSymmetric(n, int64(n))
+					e, err := s.solve(A)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if len(e) != n {
+						t.Fatalf("amount of eigenvalues: %d != %d", len(e), n)
+					}
+
+					var es mat.EigenSym
+					data := make([]float64, 0, n*n)
+					for i := range A {
+						data = append(data, A[i]...)
+					}
+					if !es.Factorize(mat.NewSymDense(n, data), false) {
+						t.Fatal("cannot factorize")
+					}
+					values := es.Values(nil)
+					sort.Slice(values, func(i, j int) bool {
+						return math.Abs(values[i]) > math.Abs(values[j])
+					})
+
+					for i := range e {
+						if math.Abs(e[i].𝜦-values[i]) > 1e-12 {
+							t.Errorf("eigenvalue %d: %.14e != %.14e", i, e[i].𝜦, values[i])
+						}
+						if d := residual(A, e[i]); d > 1e-12 {
+							t.Errorf("residual %d: %.5e", i, d)
+						}
+					}
+				})
+			}
+		})
+	}
+
+	t.Run("not symmetric", func(t *testing.T) {
+		_, err := jacobi([][]float64{
+			{2, -12},
+			{1, -5},
+		}, cyclicSweep)
+		if err == nil {
+			t.Fatal("error is not found")
+		}
+	})
+}
+
+func BenchmarkJacobi(b *testing.B) {
+	A := randomSymmetric(120, 1)
+	b.Run("cyclic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := jacobi(A, cyclicSweep); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := jacobiParallel(A); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
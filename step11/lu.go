@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// LU разложение матрицы A - σ·I с выбором ведущего элемента по столбцу
+type lu struct {
+	// L и U в одной матрице, диагональ L единичная
+	a [][]float64
+
+	// перестановка строк
+	piv []int
+}
+
+func luFactorize(A [][]float64, σ float64) (f lu, err error) {
+	n := len(A)
+	f.a = make([][]float64, n)
+	f.piv = make([]int, n)
+	var norm float64
+	for i := 0; i < n; i++ {
+		f.a[i] = make([]float64, n)
+		copy(f.a[i], A[i])
+		f.a[i][i] -= σ
+		f.piv[i] = i
+		for j := 0; j < n; j++ {
+			norm = math.Max(norm, math.Abs(f.a[i][j]))
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		// ведущий элемент
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(f.a[i][k]) > math.Abs(f.a[p][k]) {
+				p = i
+			}
+		}
+		if math.Abs(f.a[p][k]) <= 𝛆*norm {
+			err = fmt.Errorf("matrix is singular in column %d", k)
+			return
+		}
+		f.a[k], f.a[p] = f.a[p], f.a[k]
+		f.piv[k], f.piv[p] = f.piv[p], f.piv[k]
+
+		for i := k + 1; i < n; i++ {
+			factor := f.a[i][k] / f.a[k][k]
+			f.a[i][k] = factor
+			for j := k + 1; j < n; j++ {
+				f.a[i][j] -= factor * f.a[k][j]
+			}
+		}
+	}
+	return
+}
+
+// решение системы (A - σ·I)·x = b
+func (f lu) solve(b []float64) (x []float64) {
+	n := len(f.a)
+	x = make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = b[f.piv[i]]
+	}
+	// L·y = P·b
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			x[i] -= f.a[i][j] * x[j]
+		}
+	}
+	// U·x = y
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= f.a[i][j] * x[j]
+		}
+		x[i] /= f.a[i][i]
+	}
+	return
+}
+
+// решение системы (A - σ·I)ᵀ·x = b
+func (f lu) solveT(b []float64) (x []float64) {
+	n := len(f.a)
+	w := make([]float64, n)
+	copy(w, b)
+	// Uᵀ·z = b
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			w[i] -= f.a[j][i] * w[j]
+		}
+		w[i] /= f.a[i][i]
+	}
+	// Lᵀ·w = z
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			w[i] -= f.a[j][i] * w[j]
+		}
+	}
+	// P·x = w
+	x = make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[f.piv[i]] = w[i]
+	}
+	return
+}
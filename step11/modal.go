@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// Модальный анализ
+//
+// По собственным парам K·x = λ·M·x с нормировкой xᵀ·M·x = 1:
+//
+//	круговая частота  ω = √λ
+//	частота           f = ω / 2π
+//	период            T = 1 / f
+//
+// Для вектора направления r (перемещение как жесткого целого):
+//
+//	коэффициент участия    Γ = xᵀ·M·r
+//	эффективная масса      m = Γ²
+//	полная масса           rᵀ·M·r = Σ m по всем формам
+
+// форма колебаний
+type mode struct {
+	𝜦 float64
+	ω float64
+	f float64
+	T float64
+	𝑿 []float64
+
+	// по направлениям
+	participation []float64 // Γ
+	effectiveMass []float64 // m
+	ratio         []float64 // m / (rᵀ·M·r)
+	cumulative    []float64 // Σ ratio по формам до текущей включительно
+}
+
+// результат модального анализа
+type modal struct {
+	modes []mode
+
+	// полная масса по направлениям rᵀ·M·r
+	totalMass []float64
+}
+
+// Модальный анализ для nev низших форм, при nev <= 0 для всех форм.
+// Направления - вектора перемещения как жесткого целого.
+func modalAnalysis(K, M [][]float64, directions [][]float64, nev int) (m modal, err error) {
+	n := len(K)
+	for d := range directions {
+		if len(directions[d]) != n {
+			err = fmt.Errorf("size of direction %d is %d, not %d", d, len(directions[d]), n)
+			return
+		}
+	}
+
+	e, err := generalized(K, M)
+	if err != nil {
+		return
+	}
+	if nev <= 0 || nev > len(e) {
+		nev = len(e)
+	}
+
+	Mr := make([][]float64, len(directions))
+	m.totalMass = make([]float64, len(directions))
+	for d, r := range directions {
+		Mr[d] = make([]float64, n)
+		dense(M).mul(Mr[d], r)
+		for i := range r {
+			m.totalMass[d] += r[i] * Mr[d][i]
+		}
+		if m.totalMass[d] <= 0.0 {
+			err = fmt.Errorf("mass in direction %d is not positive: %.5e", d, m.totalMass[d])
+			return
+		}
+	}
+
+	// допуск для форм жесткого смещения
+	var norm float64
+	for i := range e {
+		norm = math.Max(norm, math.Abs(e[i].𝜦))
+	}
+	tol := math.Sqrt(𝛆) * norm
+
+	for i := 0; i < nev; i++ {
+		l := e[i].𝜦
+		if l < -tol {
+			err = fmt.Errorf("eigenvalue %d is negative: %.14e", i, l)
+			return
+		}
+		md := mode{𝜦: l, 𝑿: e[i].𝑿, T: math.Inf(1)}
+		if l > tol {
+			md.ω = math.Sqrt(l)
+			md.f = md.ω / (2.0 * math.Pi)
+			md.T = 1.0 / md.f
+		}
+		for d := range directions {
+			var Γ float64
+			for k := range md.𝑿 {
+				Γ += md.𝑿[k] * Mr[d][k]
+			}
+			mass := Γ * Γ
+			ratio := mass / m.totalMass[d]
+			cumulative := ratio
+			if i > 0 {
+				cumulative += m.modes[i-1].cumulative[d]
+			}
+			md.participation = append(md.participation, Γ)
+			md.effectiveMass = append(md.effectiveMass, mass)
+			md.ratio = append(md.ratio, ratio)
+			md.cumulative = append(md.cumulative, cumulative)
+		}
+		m.modes = append(m.modes, md)
+	}
+	return
+}
+
+// Проверка, что учтенные формы набирают долю target
+// полной массы по каждому направлению, например 0.9
+func (m modal) check(target float64) error {
+	if len(m.modes) == 0 {
+		return fmt.Errorf("modes are not found")
+	}
+	last := m.modes[len(m.modes)-1]
+	for d := range m.totalMass {
+		if last.cumulative[d] < target {
+			return fmt.Errorf("direction %d: mass participation of %d modes is %.2f%% < %.2f%%",
+				d, len(m.modes), last.cumulative[d]*100, target*100)
+		}
+	}
+	return nil
+}
+
+// таблица форм
+func (m modal) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%4s %14s %14s %14s %14s", "mode", "λ", "ω", "f", "T")
+	for d := range m.totalMass {
+		fmt.Fprintf(&buf, " %14s %14s %8s %8s",
+			fmt.Sprintf("Γ%d", d), fmt.Sprintf("mass%d", d), "%", "Σ%")
+	}
+	fmt.Fprintln(&buf)
+	for i, md := range m.modes {
+		fmt.Fprintf(&buf, "%4d %14.5e %14.5e %14.5e %14.5e", i+1, md.𝜦, md.ω, md.f, md.T)
+		for d := range m.totalMass {
+			fmt.Fprintf(&buf, " %14.5e %14.5e %8.2f %8.2f",
+				md.participation[d], md.effectiveMass[d],
+				md.ratio[d]*100, md.cumulative[d]*100)
+		}
+		fmt.Fprintln(&buf)
+	}
+	return buf.String()
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeneralized(t *testing.T) {
+	K := randomSymmetric(6, 11)
+	M := randomSymmetric(6, 12)
+	// положительно определенная матрица масс
+	for i := range M {
+		M[i][i] = 10.0 + float64(i)
+	}
+	e, err := generalized(K, M)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range e {
+		x := e[i].𝑿
+		Kx := make([]float64, len(x))
+		Mx := make([]float64, len(x))
+		dense(K).mul(Kx, x)
+		dense(M).mul(Mx, x)
+		var r, xMx float64
+		for k := range x {
+			r = math.Max(r, math.Abs(Kx[k]-e[i].𝜦*Mx[k]))
+			xMx += x[k] * Mx[k]
+		}
+		if r > 1e-12 || math.Abs(xMx-1) > 1e-12 {
+			t.Errorf("eigenpair %d: residual %v, xᵀ·M·x = %v", i, r, xMx)
+		}
+		if i > 0 && e[i-1].𝜦 > e[i].𝜦 {
+			t.Errorf("eigenvalues is not increasing")
+		}
+	}
+
+	for _, tc := range []struct{ K, M [][]float64 }{
+		{K: [][]float64{{1, 2}, {0, 1}}, M: [][]float64{{1, 0}, {0, 1}}},
+		{K: [][]float64{{1, 0}, {0, 1}}, M: [][]float64{{1, 0}, {0, 0}}},
+		{K: [][]float64{{1, 0}, {0, 1}}, M: [][]float64{{1}}},
+	} {
+		if _, err := generalized(tc.K, tc.M); err == nil {
+			t.Errorf("error is not found: %v %v", tc.K, tc.M)
+		}
+	}
+}
+
+func TestModal(t *testing.T) {
+	// две массы m на пружинах k: земля - k - m - k - m
+	k, m := 1000.0, 2.0
+	K := [][]float64{
+		{2 * k, -k},
+		{-k, k},
+	}
+	M := [][]float64{
+		{m, 0},
+		{0, m},
+	}
+	r := [][]float64{{1, 1}}
+
+	res, err := modalAnalysis(K, M, r, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log("\n" + res.String())
+
+	if res.totalMass[0] != 2*m {
+		t.Errorf("total mass: %v", res.totalMass[0])
+	}
+	var sum float64
+	for i, md := range res.modes {
+		l := k / m * (3 + []float64{-1, 1}[i]*math.Sqrt(5)) / 2
+		if math.Abs(md.𝜦-l) > 1e-10*l {
+			t.Errorf("mode %d: λ = %.14e != %.14e", i, md.𝜦, l)
+		}
+		if math.Abs(md.ω*md.ω-md.𝜦) > 1e-10*l ||
+			math.Abs(md.f*2*math.Pi-md.ω) > 1e-12*md.ω ||
+			math.Abs(md.T*md.f-1) > 1e-14 {
+			t.Errorf("mode %d: ω = %v, f = %v, T = %v", i, md.ω, md.f, md.T)
+		}
+		sum += md.effectiveMass[0]
+	}
+	if math.Abs(sum-2*m) > 1e-12 {
+		t.Errorf("sum of effective mass: %v", sum)
+	}
+	if c := res.modes[1].cumulative[0]; math.Abs(c-1) > 1e-12 {
+		t.Errorf("cumulative: %v", c)
+	}
+
+	// первая форма: x = (1, φ), доля массы (1+φ)² / (2·(1+φ²))
+	φ := (1 + math.Sqrt(5)) / 2
+	ratio := (1 + φ) * (1 + φ) / (2 * (1 + φ*φ))
+	res, err = modalAnalysis(K, M, r, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.modes) != 1 || math.Abs(res.modes[0].ratio[0]-ratio) > 1e-12 {
+		t.Errorf("ratio: %v != %v", res.modes[0].ratio[0], ratio)
+	}
+	if err := res.check(0.9); err != nil {
+		t.Error(err)
+	}
+	if err := res.check(0.95); err == nil {
+		t.Errorf("error is not found")
+	} else {
+		t.Log(err)
+	}
+
+	t.Run("rigid body", func(t *testing.T) {
+		// свободная система: m - k - m
+		res, err := modalAnalysis([][]float64{
+			{k, -k},
+			{-k, k},
+		}, M, r, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		md := res.modes[0]
+		if md.ω != 0 || md.f != 0 || !math.IsInf(md.T, 1) ||
+			math.Abs(md.ratio[0]-1) > 1e-12 {
+			t.Errorf("rigid body mode: %v", md)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := modalAnalysis(K, M, [][]float64{{1}}, 0); err == nil {
+			t.Errorf("size of direction: error is not found")
+		}
+		if _, err := modalAnalysis(K, M, [][]float64{{0, 0}}, 0); err == nil {
+			t.Errorf("zero direction: error is not found")
+		}
+		if _, err := modalAnalysis([][]float64{{-1, 0}, {0, 1}}, M, r, 0); err == nil {
+			t.Errorf("negative eigenvalue: error is not found")
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Степенной метод с несколькими начальными векторами
+//
+// Если начальный вектор не содержит составляющей собственного
+// вектора λ1, то степенной метод сходится к другой собственной
+// паре. Несколько независимых стартов выполняются параллельно,
+// результаты сравниваются между собой: первой возвращается
+// сошедшаяся пара с наибольшим |λ|, затем остальные различные
+// собственные пары.
+
+// количество независимых стартов
+var multiStarts int = 4
+
+// результат одного старта
+type startResult struct {
+	e   eigen
+	r   float64 // невязка || A·x - λ·x || / || x ||
+	err error
+}
+
+func pmMulti(A [][]float64) (es []eigen, err error) {
+	if err = checkInput(A); err != nil {
+		return
+	}
+	n := len(A)
+
+	// для случая матрица 1х1
+	if n == 1 {
+		es = []eigen{{𝑿: []float64{1.0}, 𝜦: A[0][0]}}
+		return
+	}
+
+	// Первый старт использует общий начальный вектор,
+	// остальные - случайные векторы с фиксированным зерном
+	// для воспроизводимости.
+	starts := make([][]float64, multiStarts)
+	for s := range starts {
+		starts[s] = make([]float64, n)
+		if s == 0 {
+			initialize(starts[s])
+			continue
+		}
+		r := rand.New(rand.NewSource(int64(s)))
+		for i := range starts[s] {
+			starts[s][i] = r.Float64() - 0.5
+		}
+	}
+
+	results := make([]startResult, len(starts))
+	var wg sync.WaitGroup
+	for s := range starts {
+		wg.Add(1)
+		go func(s int) {
+			defer wg.Done()
+			if err := checkSolverStart(dense(A), starts[s]); err != nil {
+				results[s].err = err
+				return
+			}
+			results[s] = pmStart(A, starts[s])
+		}(s)
+	}
+	wg.Wait()
+
+	// сошедшиеся старты
+	var (
+		converged []startResult
+		errs      []string
+	)
+	tol := math.Sqrt(𝛆) * normInf(A)
+	for s, res := range results {
+		if res.err == nil && res.r > tol {
+			res.err = fmt.Errorf("residual is too big: %.5e", res.r)
+		}
+		if res.err != nil {
+			errs = append(errs, fmt.Sprintf("start %d: %v", s, res.err))
+			continue
+		}
+		converged = append(converged, res)
+	}
+	if output {
+		fmt.Printf("multistart: %d of %d starts are converged\n",
+			len(converged), len(results))
+	}
+	if len(converged) == 0 {
+		err = fmt.Errorf("%s", strings.Join(errs, "\n"))
+		return
+	}
+
+	// по убыванию |λ|, для равных - по невязке
+	sort.SliceStable(converged, func(i, j int) bool {
+		li, lj := math.Abs(converged[i].e.𝜦), math.Abs(converged[j].e.𝜦)
+		if math.Abs(li-lj) > math.Sqrt(𝛆)*math.Max(li, lj) {
+			return li > lj
+		}
+		return converged[i].r < converged[j].r
+	})
+
+	// только различные собственные пары
+	for _, res := range converged {
+		distinct := true
+		for _, e := range es {
+			if math.Abs(cosine(e.𝑿, res.e.𝑿)) > 1-math.Sqrt(𝛆) {
+				distinct = false
+				break
+			}
+		}
+		if distinct {
+			es = append(es, res.e)
+		}
+	}
+	err = normalizing.apply(es)
+	return
+}
+
+// один старт степенного метода
+func pmStart(A [][]float64, x []float64) (res startResult) {
+	var iter int64
+	mul := func(y, x []float64) {
+		iter++
+		dense(A).mul(y, x)
+	}
+	var stop string
+	if _, stop, res.err = power(mul, x); res.err != nil {
+		return
+	}
+
+	// λ по наибольшему элементу вектора, так как для
+	// несимметричной матрицы отношение Рэлея неточное
+	index := 0
+	for i := range x {
+		if math.Abs(x[i]) > math.Abs(x[index]) {
+			index = i
+		}
+	}
+	y := make([]float64, len(x))
+	dense(A).mul(y, x)
+	l := y[index] / x[index]
+
+	res.e = eigen{𝑿: x, 𝜦: l, iterations: iter, stop: stop}
+	res.r = residualNorm(A, x, l)
+	return
+}
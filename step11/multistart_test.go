@@ -0,0 +1,133 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPmMulti(t *testing.T) {
+	old := initialize
+	defer func() {
+		initialize = old
+	}()
+
+	t.Run("start on eigenvector of λ2", func(t *testing.T) {
+		A := [][]float64{
+			{5, 1, 0},
+			{1, 3, 0},
+			{0, 0, 1},
+		}
+		// собственный вектор λ3 = 1
+		initialize = func(x []float64) {
+			for i := range x {
+				x[i] = 0.0
+			}
+			x[2] = 1.0
+		}
+
+		// обычный степенной метод находит не ту пару
+		es, err := pm(A)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(es[0].𝜦-1) > 1e-12 {
+			t.Fatalf("pm: %v", es[0].𝜦)
+		}
+
+		es, err = pmMulti(A)
+		if err != nil {
+			t.Fatal(err)
+		}
+		λ1 := 4 + math.Sqrt(2)
+		if math.Abs(es[0].𝜦-λ1) > 1e-12 {
+			t.Errorf("λ1: %.14e != %.14e", es[0].𝜦, λ1)
+		}
+		if r := residual(A, es[0]); r > 1e-12 {
+			t.Errorf("residual: %v", r)
+		}
+		found := false
+		for _, e := range es[1:] {
+			if math.Abs(e.𝜦-1) < 1e-12 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("pair of λ3 is not found: %v", es)
+		}
+	})
+
+	t.Run("symmetric", func(t *testing.T) {
+		initialize = old
+		A := randomSymmetric(8, 3)
+		es, err := pmMulti(A)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ej, err := jacobi(A, cyclicSweep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := ej[0].𝜦; math.Abs(es[0].𝜦-d) > 1e-10*math.Abs(d) {
+			t.Errorf("%.14e != %.14e", es[0].𝜦, d)
+		}
+		for i := 1; i < len(es); i++ {
+			if math.Abs(cosine(es[0].𝑿, es[i].𝑿)) > 1-1e-8 {
+				t.Errorf("eigenpair %d is not distinct", i)
+			}
+		}
+	})
+
+	t.Run("sign-alternating pair", func(t *testing.T) {
+		initialize = old
+		A := [][]float64{
+			{0, 1},
+			{1, 0},
+		}
+		es, err := pmMulti(A)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(math.Abs(es[0].𝜦)-1) > 1e-12 {
+			t.Errorf("eigenvalue: %.14e", es[0].𝜦)
+		}
+		if r := residual(A, es[0]); r > 1e-12 {
+			t.Errorf("residual: %v", r)
+		}
+	})
+
+	t.Run("all starts fail", func(t *testing.T) {
+		initialize = old
+		// комплексные собственные значения ±i
+		_, err := pmMulti([][]float64{
+			{0, 1},
+			{-1, 0},
+		})
+		if err == nil {
+			t.Fatalf("error is not found")
+		}
+		t.Log(err)
+	})
+	t.Run("normalization", func(t *testing.T) {
+		defer harmonicStart()()
+		oldNormalizing := normalizing
+		normalizing = normalizer{mode: unitNorm, sign: true}
+		defer func() {
+			normalizing = oldNormalizing
+		}()
+
+		A := randomSymmetric(5, 2)
+		es, err := pmMulti(A)
+		if err != nil {
+			t.Fatal(err)
+		}
+		single, err := pm(A)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range es[0].𝑿 {
+			if math.Abs(es[0].𝑿[i]-single[0].𝑿[i]) > 1e-8 {
+				t.Fatalf("vectors are not same: %v != %v", es[0].𝑿, single[0].𝑿)
+			}
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Нормализация собственных векторов
+//
+// oneMax делит вектор на наибольший по модулю элемент, поэтому
+// знак вектора зависит от знака этого элемента. Для обработки
+// результатов МКЭ нужны другие нормализации:
+//
+//	maxComponent - наибольший элемент равен 1 (oneMax)
+//	unitNorm     - ||x|| = 1
+//	massNorm     - xᵀ·M·x = 1
+//	unitDOF      - x[dof] = 1
+//
+// Соглашение о знаке: первый ненулевой элемент положительный.
+
+// вид нормализации
+type normalization int
+
+const (
+	maxComponent normalization = iota
+	unitNorm
+	massNorm
+	unitDOF
+)
+
+// нормализация собственных векторов
+type normalizer struct {
+	mode normalization
+
+	// матрица масс для massNorm
+	M [][]float64
+
+	// степень свободы для unitDOF
+	dof int
+
+	// первый ненулевой элемент положительный.
+	// Для unitDOF знак определяется степенью свободы.
+	sign bool
+}
+
+// нормализация результатов решателей exh и pm
+var normalizing normalizer
+
+func (nz normalizer) apply(e []eigen) (err error) {
+	for i := range e {
+		if err = nz.vector(e[i].𝑿); err != nil {
+			err = fmt.Errorf("normalization of eigenvector %d: %v", i, err)
+			return
+		}
+	}
+	return
+}
+
+func (nz normalizer) vector(x []float64) (err error) {
+	var max float64
+	for i := range x {
+		max = math.Max(max, math.Abs(x[i]))
+	}
+	if max == 0.0 {
+		return fmt.Errorf("all values of eigenvector is zeros")
+	}
+
+	var factor float64
+	switch nz.mode {
+	case maxComponent:
+		_, err = oneMax(x, x)
+		factor, max = 1.0, 1.0
+
+	case unitNorm:
+		for i := range x {
+			factor += x[i] * x[i]
+		}
+		factor = math.Sqrt(factor)
+
+	case massNorm:
+		if len(nz.M) != len(x) {
+			return fmt.Errorf("size of mass matrix %d is not same as %d", len(nz.M), len(x))
+		}
+		Mx := make([]float64, len(x))
+		dense(nz.M).mul(Mx, x)
+		for i := range x {
+			factor += x[i] * Mx[i]
+		}
+		if factor <= 0.0 {
+			return fmt.Errorf("mass matrix is not positive: xᵀ·M·x = %.5e", factor)
+		}
+		factor = math.Sqrt(factor)
+
+	case unitDOF:
+		if nz.dof < 0 || len(x) <= nz.dof {
+			return fmt.Errorf("dof %d is outside of vector with size %d", nz.dof, len(x))
+		}
+		factor = x[nz.dof]
+		if math.Abs(factor) <= 𝛆*100*max {
+			return fmt.Errorf("component of dof %d is zero", nz.dof)
+		}
+
+	default:
+		return fmt.Errorf("normalization %d is not supported", nz.mode)
+	}
+	if err != nil {
+		return
+	}
+
+	if nz.sign && nz.mode != unitDOF {
+		for i := range x {
+			if math.Abs(x[i]) > 𝛆*100*max {
+				if x[i] < 0.0 {
+					factor = -factor
+				}
+				break
+			}
+		}
+	}
+
+	for i := range x {
+		x[i] /= factor
+	}
+	return
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalization(t *testing.T) {
+	M := [][]float64{
+		{2, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+	x := []float64{0, -2, 1}
+
+	tcs := []struct {
+		name   string
+		nz     normalizer
+		expect []float64
+	}{
+		{name: "max", nz: normalizer{}, expect: []float64{0, 1, -0.5}},
+		{name: "max with sign", nz: normalizer{sign: true}, expect: []float64{0, 1, -0.5}},
+		{name: "unit", nz: normalizer{mode: unitNorm}, expect: []float64{0, -2 / math.Sqrt(5), 1 / math.Sqrt(5)}},
+		{name: "unit with sign", nz: normalizer{mode: unitNorm, sign: true}, expect: []float64{0, 2 / math.Sqrt(5), -1 / math.Sqrt(5)}},
+		{name: "mass", nz: normalizer{mode: massNorm, M: M, sign: true}, expect: []float64{0, 2 / math.Sqrt(5), -1 / math.Sqrt(5)}},
+		{name: "dof", nz: normalizer{mode: unitDOF, dof: 2, sign: true}, expect: []float64{0, -2, 1}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			e := []eigen{{𝑿: append([]float64{}, x...)}}
+			if err := tc.nz.apply(e); err != nil {
+				t.Fatal(err)
+			}
+			for i := range x {
+				if math.Abs(e[0].𝑿[i]-tc.expect[i]) > 1e-15 {
+					t.Fatalf("%v != %v", e[0].𝑿, tc.expect)
+				}
+			}
+		})
+	}
+
+	t.Run("mass-normalized", func(t *testing.T) {
+		y := []float64{1, 3, -2}
+		if err := (normalizer{mode: massNorm, M: M}).vector(y); err != nil {
+			t.Fatal(err)
+		}
+		var yMy float64
+		for i := range y {
+			yMy += y[i] * M[i][i] * y[i]
+		}
+		if math.Abs(yMy-1) > 1e-15 {
+			t.Errorf("xᵀ·M·x = %v", yMy)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, nz := range []normalizer{
+			{mode: massNorm},
+			{mode: massNorm, M: [][]float64{{-1, 0, 0}, {0, -1, 0}, {0, 0, -1}}},
+			{mode: unitDOF, dof: 0},
+			{mode: unitDOF, dof: 3},
+			{mode: normalization(100)},
+		} {
+			if err := nz.vector(append([]float64{}, x...)); err == nil {
+				t.Errorf("%v: error is not found", nz)
+			}
+		}
+		if err := (normalizer{}).vector([]float64{0, 0}); err == nil {
+			t.Errorf("zero vector: error is not found")
+		}
+	})
+
+	t.Run("solvers", func(t *testing.T) {
+		old, oldDeflation := normalizing, deflation
+		defer func() {
+			normalizing, deflation = old, oldDeflation
+		}()
+		normalizing = normalizer{mode: unitNorm, sign: true}
+		deflation = implicit
+
+		A := randomSymmetric(5, 2)
+		for name, solver := range map[string]func([][]float64) ([]eigen, error){
+			"exh": exh,
+			"pm":  pm,
+		} {
+			es, err := solver(A)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			for i := range es {
+				var xx float64
+				for _, v := range es[i].𝑿 {
+					xx += v * v
+				}
+				if math.Abs(xx-1) > 1e-14 || es[i].𝑿[0] < 0 {
+					t.Errorf("%s: eigenvector %d: %v", name, i, es[i].𝑿)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// линейный оператор y = A·x
+//
+// Оператор может быть плотной матрицей, разреженной матрицей
+// или функцией без хранения матрицы.
+type operator interface {
+	// размер оператора
+	size() int
+
+	// y = A·x
+	mul(y, x []float64)
+}
+
+// оператор с умножением на транспонированную матрицу
+type transposer interface {
+	operator
+
+	// y = Aᵀ·x
+	mulT(y, x []float64)
+}
+
+// плотная матрица
+type dense [][]float64
+
+func (A dense) size() int {
+	return len(A)
+}
+
+func (A dense) mul(y, x []float64) {
+	for row := range A {
+		y[row] = 0.0
+		for col := range A[row] {
+			y[row] += A[row][col] * x[col]
+		}
+	}
+}
+
+func (A dense) mulT(y, x []float64) {
+	for row := range y {
+		y[row] = 0.0
+	}
+	for row := range A {
+		for col := range A[row] {
+			y[col] += A[row][col] * x[row]
+		}
+	}
+}
+
+// разреженная матрица в координатном формате
+type sparse struct {
+	n   int
+	row []int
+	col []int
+	val []float64
+}
+
+// добавление значения в ячейку матрицы
+func (s *sparse) add(row, col int, val float64) {
+	if val == 0.0 {
+		return
+	}
+	s.row = append(s.row, row)
+	s.col = append(s.col, col)
+	s.val = append(s.val, val)
+}
+
+func (s *sparse) size() int {
+	return s.n
+}
+
+func (s *sparse) mul(y, x []float64) {
+	for i := range y {
+		y[i] = 0.0
+	}
+	for k := range s.val {
+		y[s.row[k]] += s.val[k] * x[s.col[k]]
+	}
+}
+
+func (s *sparse) mulT(y, x []float64) {
+	for i := range y {
+		y[i] = 0.0
+	}
+	for k := range s.val {
+		y[s.col[k]] += s.val[k] * x[s.row[k]]
+	}
+}
+
+// транспонированный оператор
+type transposed struct {
+	A transposer
+}
+
+func (t transposed) size() int {
+	return t.A.size()
+}
+
+func (t transposed) mul(y, x []float64) {
+	t.A.mulT(y, x)
+}
+
+func (t transposed) mulT(y, x []float64) {
+	t.A.mul(y, x)
+}
+
+// оператор, заданный функцией умножения
+type mulFunc struct {
+	n int
+	f func(y, x []float64)
+}
+
+func (m mulFunc) size() int {
+	return m.n
+}
+
+func (m mulFunc) mul(y, x []float64) {
+	m.f(y, x)
+}
+
+// λ = (Ax , x) / (x , x)
+func rayleigh(A operator, x []float64) float64 {
+	Ax := make([]float64, len(x))
+	A.mul(Ax, x)
+	var Axx, xx float64
+	for i := range x {
+		Axx += Ax[i] * x[i]
+		xx += x[i] * x[i]
+	}
+	return Axx / xx
+}
+
+// Степенной метод для оператора.
+// Встроенный критерий сходимости по изменению нормирующего
+// множителя срабатывает раньше сходимости вектора, если наибольший
+// элемент вектора уже не меняется. Поэтому итерации идут
+// до сходимости вектора или до уровня ошибок округления.
+// Если задан критерий convergence, то используется он.
+// Возвращается описание сработавшего критерия stop.
+//
+// Для знакопеременной пары λ1 = -λ2 вектор x(k) повторяется
+// через итерацию. В этом случае вектора пары разделяются
+// в powerPair: x - вектор для λ, second - вектор для -λ.
+// Для обычной сходимости second = nil.
+func power(mul func(y, x []float64), x []float64) (second []float64, stop string, err error) {
+	n := len(x)
+	var maxIteration int64 = 5000
+	z := make([]float64, n)
+	xLast := make([]float64, n)
+	xPrev2 := make([]float64, n)
+	it := iteration{A: mulFunc{n: n, f: mul}, x: x, xLast: xLast}
+	change, changeLast := math.MaxFloat64, math.MaxFloat64
+	for it.number = 1; ; it.number++ {
+		if it.number > maxIteration {
+			err = fmt.Errorf("Iteration limit")
+			return
+		}
+
+		// z(k) = A · x(k-1)
+		mul(z, x)
+		copy(xPrev2, xLast)
+		copy(xLast, x)
+		it.lLast = it.l
+		if it.l, err = oneMax(x, z); err != nil {
+			return
+		}
+
+		// ||x(k)-x(k-1)|| и ||x(k)-x(k-2)||
+		var change2 float64
+		changeLast, change = change, 0.0
+		for i := range x {
+			change = math.Max(change, math.Abs(x[i]-xLast[i]))
+			change2 = math.Max(change2, math.Abs(x[i]-xPrev2[i]))
+		}
+
+		if output {
+			fmt.Printf("iter: %2d\tx=", it.number)
+			for i := range x {
+				fmt.Printf("\t%10.5e", x[i])
+			}
+			fmt.Printf("\t𝛆 = %10.5e\n", change)
+		}
+
+		// знакопеременная пара ±λ
+		if it.number > 2 && change > math.Sqrt(𝛆) && change2 <= math.Sqrt(𝛆)*change {
+			if output {
+				fmt.Println("power: sign-alternating pair")
+			}
+			_, second, stop, err = powerPair(mul, x)
+			stop = "sign-alternating pair, " + stop
+			return
+		}
+
+		if convergence != nil {
+			if it.number >= 3 {
+				if stop = convergence.check(it); stop != "" {
+					return
+				}
+			}
+			continue
+		}
+		if change < 𝛆*float64(100*n) ||
+			(change < math.Sqrt(𝛆) && change >= changeLast) {
+			stop = fmt.Sprintf("vector change %.5e", change)
+			return
+		}
+	}
+}
+
+// Разделение знакопеременной пары ±λ. Итерации идут
+// с оператором A², собственное значение которого λ², затем
+//
+//	A·(x ± A·x/λ) = ±λ·(x ± A·x/λ)
+//
+// В x возвращается вектор для l, в second - для -l.
+// Если x уже является собственным вектором, то second = nil.
+func powerPair(mul func(y, x []float64), x []float64) (l float64, second []float64, stop string, err error) {
+	n := len(x)
+	t := make([]float64, n)
+	A2 := func(y, x []float64) {
+		mul(t, x)
+		mul(y, t)
+	}
+	if _, stop, err = power(A2, x); err != nil {
+		return
+	}
+
+	// λ² по наибольшему элементу вектора
+	index := 0
+	for i := range x {
+		if math.Abs(x[i]) > math.Abs(x[index]) {
+			index = i
+		}
+	}
+	y := make([]float64, n)
+	A2(y, x)
+	μ := y[index] / x[index]
+	if μ <= 0.0 {
+		err = fmt.Errorf("eigenvalues of pair is not real: λ² = %.14e", μ)
+		return
+	}
+	l = math.Sqrt(μ)
+
+	// x ± A·x/λ
+	Ax := make([]float64, n)
+	mul(Ax, x)
+	var vs [][]float64
+	var signs []float64
+	for _, sign := range []float64{1, -1} {
+		u := make([]float64, n)
+		var uMax float64
+		for i := range u {
+			u[i] = x[i] + sign*Ax[i]/l
+			uMax = math.Max(uMax, math.Abs(u[i]))
+		}
+		// вектор x уже является собственным вектором
+		if uMax <= math.Sqrt(𝛆) {
+			continue
+		}
+		oneMax(u, u)
+		vs = append(vs, u)
+		signs = append(signs, sign)
+	}
+	if len(vs) == 0 {
+		err = fmt.Errorf("vectors of pair are not found")
+		return
+	}
+	copy(x, vs[0])
+	l *= signs[0]
+	if len(vs) == 2 {
+		second = vs[1]
+	}
+	return
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// оператор второй разности без хранения матрицы:
+// y[i] = 2·x[i] - x[i-1] - x[i+1]
+type laplacian int
+
+func (l laplacian) size() int {
+	return int(l)
+}
+
+func (l laplacian) mul(y, x []float64) {
+	n := int(l)
+	for i := 0; i < n; i++ {
+		y[i] = 2.0 * x[i]
+		if i > 0 {
+			y[i] -= x[i-1]
+		}
+		if i < n-1 {
+			y[i] -= x[i+1]
+		}
+	}
+}
+
+// точные собственные значения оператора второй разности
+// по убыванию: 2 - 2·cos(k·π/(n+1))
+func laplacianValues(n int) (values []float64) {
+	for k := n; k >= 1; k-- {
+		values = append(values, 2.0-2.0*math.Cos(float64(k)*math.Pi/float64(n+1)))
+	}
+	return
+}
+
+func TestOperator(t *testing.T) {
+	defer harmonicStart()()
+
+	const n = 6
+	s := &sparse{n: n}
+	for i := 0; i < n; i++ {
+		s.add(i, i, 2.0)
+		if i > 0 {
+			s.add(i, i-1, -1.0)
+		}
+		if i < n-1 {
+			s.add(i, i+1, -1.0)
+		}
+	}
+
+	tcs := []struct {
+		name string
+		A    operator
+		nev  int
+	}{
+		{name: "sparse", A: s, nev: n},
+		{name: "matrix-free", A: laplacian(n), nev: n},
+		{name: "matrix-free, 3 eigenvalues", A: laplacian(n), nev: 3},
+	}
+
+	values := laplacianValues(n)
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := exhOperator(tc.A, tc.nev)
+			PrintEigens(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(e) != tc.nev {
+				t.Fatalf("amount of eigenvalues: %d != %d", len(e), tc.nev)
+			}
+			Ax := make([]float64, n)
+			for i := range e {
+				if math.Abs(e[i].𝜦-values[i]) > 1e-10 {
+					t.Errorf("eigenvalue %d: %.14e != %.14e", i, e[i].𝜦, values[i])
+				}
+				tc.A.mul(Ax, e[i].𝑿)
+				for j := range Ax {
+					if d := math.Abs(Ax[j] - e[i].𝜦*e[i].𝑿[j]); d > 1e-6 {
+						t.Errorf("residual %d: %.5e", i, d)
+						break
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("sparse transpose", func(t *testing.T) {
+		s := &sparse{n: 2}
+		s.add(0, 1, 3.0)
+		s.add(1, 0, 1.0)
+		y := make([]float64, 2)
+		s.mulT(y, []float64{1, 2})
+		if y[0] != 2.0 || y[1] != 3.0 {
+			t.Errorf("not valid: %v", y)
+		}
+	})
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Степенной метод с оценкой скорости сходимости
+//
+// Поправки нормирующего множителя δ(k) = max(k) - max(k-1)
+// убывают как |λ2/λ1|^k, поэтому отношение поправок
+// ρ = δ(k)/δ(k-1) является оценкой λ2/λ1.
+//
+// Экстраполяция Эйткена Δ² последовательности max(k):
+//
+//	λ* = max(k) - δ(k)² / (δ(k) - δ(k-1))
+//
+// сходится быстрее исходной последовательности, поэтому
+// итерации заканчиваются по изменению λ*.
+//
+// Для знакопеременной пары λ1 = -λ2 вектор x(k) повторяется
+// через итерацию. В этом случае итерации идут
+// с матрицей A², собственное значение которой λ², а вектора
+// пары разделяются:
+//
+//	A·(x ± A·x/λ) = ±λ·(x ± A·x/λ)
+
+// использовать экстраполяцию Эйткена
+var extrapolation bool = true
+
+// максимальное количество итераций степенного метода
+var pmMaxIteration int64 = 5000
+
+func pm(A [][]float64) (es []eigen, err error) {
+	if err = checkInput(A); err != nil {
+		return
+	}
+
+	// автоматический сдвиг спектра
+	A, σ := shifting.apply(A)
+	if σ != 0.0 {
+		defer func() {
+			for i := range es {
+				es[i].𝜦 += σ
+			}
+		}()
+	}
+	n := len(A)
+
+	// для случая матрица 1х1
+	if n == 1 {
+		es = []eigen{{𝑿: []float64{1.0}, 𝜦: A[0][0]}}
+		return
+	}
+
+	var e eigen
+
+	x := make([]float64, n)
+	z := make([]float64, n)
+	xLast := make([]float64, n)
+	xPrev2 := make([]float64, n)
+	if err = startFor(dense(A), x); err != nil {
+		return
+	}
+
+	var (
+		max, maxLast float64 // нормирующий множитель
+		δ, δLast     float64 // поправки нормирующего множителя
+		ρ, ρLast     float64 // отношение поправок
+		aitken       float64 // экстраполированное значение
+		aitkenLast   float64
+	)
+
+	for iter := int64(1); ; iter++ {
+		// устанавливаем лимит на количество итераций
+		if iter > pmMaxIteration {
+			err = fmt.Errorf("Iteration limit")
+			return
+		}
+
+		// z(k) = A · x(k-1)
+		dense(A).mul(z, x)
+
+		// x(k) = z(k) / || z(k) ||
+		copy(xPrev2, xLast)
+		copy(xLast, x)
+		maxLast = max
+		max, err = oneMax(x, z)
+		if err != nil {
+			return
+		}
+		if iter == 1 {
+			continue
+		}
+
+		// ||x(k)-x(k-1)|| и ||x(k)-x(k-2)||
+		var change, change2 float64
+		for i := range x {
+			change = math.Max(change, math.Abs(x[i]-xLast[i]))
+			change2 = math.Max(change2, math.Abs(x[i]-xPrev2[i]))
+		}
+		if iter == 2 {
+			change2 = change
+		}
+
+		δLast, δ = δ, max-maxLast
+		if δLast != 0.0 {
+			ρLast, ρ = ρ, δ/δLast
+		}
+
+		if output {
+			fmt.Printf("iter: %2d\tmax = %.14e\tδ = %10.5e\tρ = %10.5f\n",
+				iter, max, δ, ρ)
+		}
+
+		e.𝑿 = x
+		e.𝜦 = max
+		e.iterations = iter
+
+		// знакопеременная пара ±λ
+		if change > math.Sqrt(𝛆) && change2 <= math.Sqrt(𝛆)*change {
+			if output {
+				fmt.Println("pm: sign-alternating pair")
+			}
+			if es, err = pmPair(A, x, iter); err == nil {
+				err = normalizing.apply(es)
+			}
+			return
+		}
+
+		// заданный критерий сходимости
+		if convergence != nil {
+			it := iteration{A: dense(A), x: x, xLast: xLast, l: max, lLast: maxLast, number: iter}
+			if iter >= 3 {
+				if e.stop = convergence.check(it); e.stop != "" {
+					break
+				}
+			}
+			continue
+		}
+
+		// сходимость без экстраполяции
+		if math.Abs(δ) <= 𝛆*math.Abs(max) && change <= math.Sqrt(𝛆) {
+			// на случай слишком быстрой сходимости
+			if iter < 3 {
+				continue
+			}
+			e.stop = fmt.Sprintf("eigenvalue change %.5e", math.Abs(δ/max))
+			break
+		}
+
+		// оценка отношения еще не установилась
+		if iter < 4 || math.Abs(ρ-ρLast) > 0.1*math.Abs(ρ) {
+			continue
+		}
+
+		// поправки на уровне ошибок округления не дают
+		// оценки отношения
+		if math.Abs(δ) > math.Sqrt(𝛆)*math.Abs(max) {
+			e.ratio = math.Abs(ρ)
+		}
+
+		// оставшееся количество итераций для δ(k) < 𝛆·|λ|
+		if 0 < math.Abs(ρ) && math.Abs(ρ) < 1 {
+			remaining := pmRemaining(δ, max, ρ)
+			if output {
+				fmt.Printf("remaining iterations: %d\n", remaining)
+			}
+			if iter+remaining > pmMaxIteration {
+				err = fmt.Errorf("convergence is too slow: |λ2/λ1| = %.5f, "+
+					"remaining %d iterations > limit %d",
+					math.Abs(ρ), remaining, pmMaxIteration-iter)
+				return
+			}
+		}
+
+		if !extrapolation || δ == δLast {
+			continue
+		}
+
+		// λ* = max(k) - δ(k)² / (δ(k) - δ(k-1))
+		aitkenLast, aitken = aitken, max-δ*δ/(δ-δLast)
+		if output {
+			fmt.Printf("aitken: %.14e\n", aitken)
+		}
+		if math.Abs(aitken-aitkenLast) <= 𝛆*math.Abs(aitken) && change <= math.Sqrt(𝛆) {
+			e.𝜦 = aitken
+			e.stop = fmt.Sprintf("aitken extrapolation change %.5e",
+				math.Abs((aitken-aitkenLast)/aitken))
+			break
+		}
+	}
+
+	if output {
+		fmt.Printf("pm: λ = %.14e, |λ2/λ1| = %.5f, iterations = %d, stop: %s\n",
+			e.𝜦, e.ratio, e.iterations, e.stop)
+	}
+	es = []eigen{e}
+	err = normalizing.apply(es)
+	return
+}
+
+// собственные пары ±λ по итерациям с матрицей A²
+func pmPair(A [][]float64, x []float64, iter int64) (es []eigen, err error) {
+	var muls int64
+	mul := func(y, x []float64) {
+		muls++
+		dense(A).mul(y, x)
+	}
+	l, second, stop, err := powerPair(mul, x)
+	if err != nil {
+		return
+	}
+	iter += muls / 2
+	stop = "sign-alternating pair, " + stop
+	es = append(es, eigen{𝑿: x, 𝜦: l, ratio: 1.0, iterations: iter, stop: stop})
+	if second != nil {
+		es = append(es, eigen{𝑿: second, 𝜦: -l, ratio: 1.0, iterations: iter, stop: stop})
+	}
+	return
+}
+
+// Количество итераций до δ(k) < 𝛆·|λ| при δ(k+m) = δ(k)·ρ^m
+func pmRemaining(δ, λ, ρ float64) int64 {
+	m := math.Log(𝛆*math.Abs(λ)/math.Abs(δ)) / math.Log(math.Abs(ρ))
+	if m < 0 {
+		return 0
+	}
+	return int64(math.Ceil(m))
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPm(t *testing.T) {
+	defer harmonicStart()()
+
+	tcs := []struct {
+		name  string
+		A     [][]float64
+		λ     float64
+		ratio float64
+	}{
+		{
+			name: "Low ratio : |𝜦2|/|𝜦1| = 0.1",
+			A: [][]float64{
+				{4, 5},
+				{6, 5},
+			},
+			λ:     10,
+			ratio: 0.1,
+		},
+		{
+			name: "Big ratio : |𝜦2|/|𝜦1| = 0.9",
+			A: [][]float64{
+				{-4, 10},
+				{7, 5},
+			},
+			λ:     10,
+			ratio: 0.9,
+		},
+		{
+			name:  "symmetric : |𝜦2|/|𝜦1| = 0.8",
+			A:     spectrum([]float64{-5, 4, 2, 1, -0.5}),
+			λ:     -5,
+			ratio: 0.8,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var iterations [2]int64
+			for i, ex := range []bool{false, true} {
+				oldEx := extrapolation
+				extrapolation = ex
+				es, err := pm(tc.A)
+				extrapolation = oldEx
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(es) != 1 {
+					t.Fatalf("amount of eigenvalues: %d", len(es))
+				}
+				e := es[0]
+				if math.Abs(e.𝜦-tc.λ) > 1e-13*math.Abs(tc.λ) {
+					t.Errorf("eigenvalue: %.14e != %.14e", e.𝜦, tc.λ)
+				}
+				if math.Abs(e.ratio-tc.ratio) > 1e-2 {
+					t.Errorf("ratio: %.5f != %.5f", e.ratio, tc.ratio)
+				}
+				iterations[i] = e.iterations
+			}
+			t.Logf("iterations: without extrapolation = %d, with = %d",
+				iterations[0], iterations[1])
+			if iterations[1] > iterations[0] {
+				t.Errorf("extrapolation is slower")
+			}
+		})
+	}
+
+	t.Run("sign-alternating pair", func(t *testing.T) {
+		tcs := []struct {
+			name string
+			A    [][]float64
+			λ    float64
+		}{
+			{
+				name: "No dominant: 1",
+				A: [][]float64{
+					{1, 0},
+					{0, -1},
+				},
+				λ: 1,
+			},
+			{
+				name: "Нет доминантной l1 = - l2",
+				A: generator([]eigen{
+					{𝜦: +5.0, 𝑿: []float64{1.0, 0.4, 0.0}},
+					{𝜦: -5.0, 𝑿: []float64{0.0, 0.2, 1.0}},
+					{𝜦: -1.0, 𝑿: []float64{1.0, 1.0, 1.0}},
+				}),
+				λ: 5,
+			},
+			{
+				name: "checkerboard",
+				A: [][]float64{
+					{0, 2, 0, 1},
+					{2, 0, 1, 0},
+					{0, 1, 0, 2},
+					{1, 0, 2, 0},
+				},
+				λ: 3,
+			},
+		}
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				es, err := pm(tc.A)
+				PrintEigens(es)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(es) != 2 {
+					t.Fatalf("amount of eigenvalues: %d", len(es))
+				}
+				for i, sign := range []float64{1, -1} {
+					if math.Abs(es[i].𝜦-sign*tc.λ) > 1e-12 {
+						t.Errorf("eigenvalue %d: %.14e != %.14e", i, es[i].𝜦, sign*tc.λ)
+					}
+					if d := residual(tc.A, es[i]); d > 1e-10 {
+						t.Errorf("residual %d: %.5e", i, d)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("too slow", func(t *testing.T) {
+		oldMax := pmMaxIteration
+		pmMaxIteration = 100
+		defer func() {
+			pmMaxIteration = oldMax
+		}()
+		_, err := pm(spectrum([]float64{1.0, 0.999, 0.5}))
+		if err == nil {
+			t.Fatal("error is not found")
+		}
+		t.Log(err)
+	})
+}
+
+func Example_pmRemaining() {
+	for _, ρ := range []float64{0.1, 0.5, 0.9, 0.99} {
+		fmt.Printf("|λ2/λ1| = %4.2f : %5d iterations\n", ρ, pmRemaining(1.0, 1.0, ρ))
+	}
+	// Output:
+	// |λ2/λ1| = 0.10 :    16 iterations
+	// |λ2/λ1| = 0.50 :    50 iterations
+	// |λ2/λ1| = 0.90 :   328 iterations
+	// |λ2/λ1| = 0.99 :  3437 iterations
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Уточнение собственных пар по исходной матрице
+//
+// Все собственные пары после первой находятся по матрице после
+// исчерпывания, поэтому ошибки округления накапливаются.
+// Каждая пара уточняется обратными итерациями со сдвигом
+// по исходной матрице A до невязки меньше 𝛆.
+
+// уточнять собственные пары
+var refinement bool = true
+
+// максимальное количество обратных итераций
+var refineMaxIteration int = 50
+
+func refine(A [][]float64, e []eigen) (err error) {
+	// исходные значения для проверки ухода к другой паре
+	values := make([]float64, len(e))
+	for i := range e {
+		values[i] = e[i].𝜦
+	}
+
+	var drift []string
+	for i := range e {
+		x, l, errI := inverseIteration(A, e[i].𝑿, e[i].𝜦)
+		if errI != nil {
+			err = fmt.Errorf("refinement of eigenpair %d: %v", i, errI)
+			return
+		}
+
+		// Итерации сошлись к другой собственной паре, если
+		// уточненное значение ближе к другому найденному значению
+		// или собственный вектор сильно повернулся.
+		// Оценка Бауэра-Файка по невязке не используется, так как
+		// она верна только для нормальных матриц, а A может быть
+		// несимметричной.
+		drifted := math.Abs(cosine(x, e[i].𝑿)) < 0.9
+		for j := range values {
+			// кратные собственные значения
+			if j == i || math.Abs(values[j]-values[i]) <=
+				math.Sqrt(𝛆)*math.Max(math.Abs(values[i]), math.Abs(values[j])) {
+				continue
+			}
+			if math.Abs(l-values[j]) < math.Abs(l-values[i]) {
+				drifted = true
+			}
+		}
+		if drifted {
+			drift = append(drift, fmt.Sprintf("eigenpair %d drifts from %.14e to %.14e",
+				i, e[i].𝜦, l))
+			continue
+		}
+
+		if output {
+			fmt.Printf("refinement %d: %.14e -> %.14e\n", i, e[i].𝜦, l)
+		}
+
+		oneMax(x, x)
+		e[i].𝑿 = x
+		e[i].𝜦 = l
+	}
+	if len(drift) > 0 {
+		err = fmt.Errorf("%s", strings.Join(drift, "\n"))
+	}
+	return
+}
+
+// Обратные итерации со сдвигом σ:
+//
+//	(A - σ·I) · z(k) = x(k-1)
+//	x(k) = z(k) / || z(k) ||
+//	λ = (Ax , x) / (x , x)
+func inverseIteration(A [][]float64, x0 []float64, σ float64) (x []float64, l float64, err error) {
+	n := len(A)
+
+	// для точного сдвига матрица вырождена, поэтому
+	// сдвиг немного изменяется
+	var f lu
+	for shift := 0.0; ; {
+		f, err = luFactorize(A, σ+shift)
+		if err == nil {
+			break
+		}
+		if shift != 0.0 {
+			return
+		}
+		shift = math.Sqrt(𝛆) * math.Max(math.Abs(σ), normInf(A))
+	}
+
+	x = make([]float64, n)
+	copy(x, x0)
+	normalize(x)
+	l = λ(A, x)
+
+	norm := normInf(A)
+	r, rLast := residualNorm(A, x, l), math.MaxFloat64
+	for iter := 0; ; iter++ {
+		if r <= 𝛆*norm {
+			return
+		}
+		// невязка не уменьшается - уровень ошибок округления
+		if r >= rLast && r <= math.Sqrt(𝛆)*norm {
+			return
+		}
+		if iter == refineMaxIteration {
+			break
+		}
+		x = f.solve(x)
+		normalize(x)
+		l = λ(A, x)
+		rLast, r = r, residualNorm(A, x, l)
+	}
+	err = fmt.Errorf("residual %.5e is not less %.5e after %d iterations",
+		r, math.Sqrt(𝛆)*norm, refineMaxIteration)
+	return
+}
+
+// || A·x - λ·x || / || x ||
+func residualNorm(A [][]float64, x []float64, l float64) float64 {
+	var r, xx float64
+	for row := range A {
+		var sum float64
+		for col := range A[row] {
+			sum += A[row][col] * x[col]
+		}
+		sum -= l * x[row]
+		r += sum * sum
+		xx += x[row] * x[row]
+	}
+	return math.Sqrt(r / xx)
+}
+
+// || A ||∞ - наибольшая сумма модулей строки
+func normInf(A [][]float64) (norm float64) {
+	for row := range A {
+		var sum float64
+		for col := range A[row] {
+			sum += math.Abs(A[row][col])
+		}
+		norm = math.Max(norm, sum)
+	}
+	return
+}
+
+// x = x / || x ||
+func normalize(x []float64) {
+	var xx float64
+	for i := range x {
+		xx += x[i] * x[i]
+	}
+	xx = math.Sqrt(xx)
+	for i := range x {
+		x[i] /= xx
+	}
+}
+
+// косинус угла между векторами
+func cosine(x, y []float64) float64 {
+	var xy, xx, yy float64
+	for i := range x {
+		xy += x[i] * y[i]
+		xx += x[i] * x[i]
+		yy += y[i] * y[i]
+	}
+	return xy / math.Sqrt(xx*yy)
+}
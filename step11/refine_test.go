@@ -0,0 +1,148 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLU(t *testing.T) {
+	A := [][]float64{
+		{0, 2, 1},
+		{1, 1, 1},
+		{4, -2, 3},
+	}
+	f, err := luFactorize(A, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := []float64{1, 2, 3}
+	x := f.solve(b)
+	for row := range A {
+		sum := -0.5 * x[row]
+		for col := range A[row] {
+			sum += A[row][col] * x[col]
+		}
+		if math.Abs(sum-b[row]) > 1e-14 {
+			t.Errorf("row %d: %.14e != %.14e", row, sum, b[row])
+		}
+	}
+
+	// (A - σ·I)ᵀ·x = b
+	x = f.solveT(b)
+	for col := range A {
+		sum := -0.5 * x[col]
+		for row := range A {
+			sum += A[row][col] * x[row]
+		}
+		if math.Abs(sum-b[col]) > 1e-14 {
+			t.Errorf("transposed, column %d: %.14e != %.14e", col, sum, b[col])
+		}
+	}
+
+	if _, err := luFactorize([][]float64{{1, 2}, {2, 4}}, 0.0); err == nil {
+		t.Errorf("singular matrix is not found")
+	}
+}
+
+func TestRefine(t *testing.T) {
+	// initialize
+	old := initialize
+	initialize = func(x []float64) {
+		for i := range x {
+			x[i] = 1.0 + float64(i)
+		}
+	}
+	defer func() {
+		initialize = old
+	}()
+
+	A := generator([]eigen{
+		{𝜦: +2.0, 𝑿: []float64{+0.5714286, +0.1428572, +1.0000000}},
+		{𝜦: -5.0, 𝑿: []float64{-0.6666667, -1.0000000, -1.0000000}},
+		{𝜦: -1.0, 𝑿: []float64{+0.5773503, +0.5773503, +0.5773503}},
+	})
+
+	t.Run("exh", func(t *testing.T) {
+		for _, ref := range []bool{false, true} {
+			oldRefinement := refinement
+			refinement = ref
+			e, err := exh(A)
+			refinement = oldRefinement
+			PrintEigens(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			values := []float64{-5, 2, -1}
+			for i := range e {
+				delta := math.Abs(e[i].𝜦 - values[i])
+				if output {
+					t.Logf("refinement %v. eigenvalue %d: delta = %.5e", ref, i, delta)
+				}
+				if ref && delta > 1e-13 {
+					t.Errorf("eigenvalue %d: %.14e != %.14e", i, e[i].𝜦, values[i])
+				}
+				if r := residualNorm(A, e[i].𝑿, e[i].𝜦); ref && r > 1e-13 {
+					t.Errorf("residual %d: %.5e", i, r)
+				}
+			}
+		}
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		A := [][]float64{
+			{3.0, 0, 0},
+			{0, 2.8, 0},
+			{0, 0, 1.0},
+		}
+		// приближенное значение ближе к другому собственному значению
+		e := []eigen{
+			{𝜦: 1.0000001, 𝑿: []float64{0.0, 0.0, 1.0}},
+			{𝜦: 2.85, 𝑿: []float64{1.0, 0.05, 0.0}},
+		}
+		err := refine(A, e)
+		if err == nil {
+			t.Fatal("drift is not found")
+		}
+		t.Log(err)
+		if e[1].𝜦 != 2.85 {
+			t.Errorf("drifted eigenpair is changed")
+		}
+		if math.Abs(e[0].𝜦-1.0) > 1e-15 {
+			t.Errorf("eigenvalue is not refined: %.14e", e[0].𝜦)
+		}
+	})
+	t.Run("nonsymmetric", func(t *testing.T) {
+		// Для ненормальной матрицы невязка может быть много меньше
+		// расстояния до собственного значения: для x = (1, 0.003)
+		// и λ = 1.3 невязка 2.1e-3, а ближайшее значение 1.0.
+		A := [][]float64{
+			{1.0, 100},
+			{0.0, 2.0},
+		}
+		e := []eigen{
+			{𝜦: 1.3, 𝑿: []float64{1.0, 0.003}},
+			{𝜦: 2.0, 𝑿: []float64{1.0, 0.01}},
+		}
+		if err := refine(A, e); err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(e[0].𝜦-1.0) > 1e-10 || math.Abs(e[1].𝜦-2.0) > 1e-10 {
+			t.Errorf("eigenvalues: %.14e, %.14e", e[0].𝜦, e[1].𝜦)
+		}
+	})
+	t.Run("not converged", func(t *testing.T) {
+		// поворот: собственные значения ±i, обратные итерации
+		// поворачивают вектор без уменьшения невязки
+		A := [][]float64{
+			{0, 1},
+			{-1, 0},
+		}
+		e := []eigen{{𝜦: 0.1, 𝑿: []float64{1.0, 0.0}}}
+		err := refine(A, e)
+		if err == nil {
+			t.Fatal("error is not found")
+		}
+		t.Log(err)
+	})
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Формы движения как жесткого целого
+//
+// Для незакрепленной конструкции матрица жесткости K вырождена,
+// собственные значения форм жесткого смещения равны нулю.
+// Обратные итерации с K⁻¹ невозможны, поэтому:
+//
+//  1. формы жесткого смещения находятся как нуль-пространство K;
+//  2. упругие формы находятся со сдвигом σ < 0, матрица
+//     K - σ·M положительно определена;
+//  3. упругие формы ортогонализуются к формам жесткого
+//     смещения по матрице масс.
+//
+// Со сдвигом и M = L·Lᵀ, x = L⁻ᵀ·y:
+//
+//	C·y = ν·y, C = Lᵀ·(K - σ·M)⁻¹·L, ν = 1/(λ - σ)
+
+// Отрицательный сдвиг для упругих форм.
+// Для 0 сдвиг выбирается автоматически.
+var rigidShift float64 = 0.0
+
+// Формы жесткого смещения - нуль-пространство K,
+// нормированное по матрице масс
+func rigidModes(K, M [][]float64) (rigid []eigen, err error) {
+	if err = checkSymmetric(K); err != nil {
+		return
+	}
+	n := len(K)
+	if len(M) != n {
+		err = fmt.Errorf("size of mass matrix %d is not same as stiffness %d", len(M), n)
+		return
+	}
+	if normInf(K) == 0.0 {
+		// все формы жесткого смещения
+		for i := 0; i < n; i++ {
+			x := make([]float64, n)
+			x[i] = 1.0
+			rigid = append(rigid, eigen{𝑿: x})
+		}
+	} else {
+		var e []eigen
+		if e, err = jacobi(K, cyclicSweep); err != nil {
+			return
+		}
+		tol := math.Sqrt(𝛆) * math.Abs(e[0].𝜦)
+		for i := range e {
+			if math.Abs(e[i].𝜦) <= tol {
+				rigid = append(rigid, eigen{𝑿: e[i].𝑿})
+			}
+		}
+	}
+
+	// ортонормирование Грама-Шмидта по матрице масс
+	Mx := make([]float64, n)
+	for i := range rigid {
+		x := rigid[i].𝑿
+		for j := 0; j < i; j++ {
+			dense(M).mul(Mx, rigid[j].𝑿)
+			var xMy float64
+			for k := range x {
+				xMy += x[k] * Mx[k]
+			}
+			for k := range x {
+				x[k] -= xMy * rigid[j].𝑿[k]
+			}
+		}
+		if err = (normalizer{mode: massNorm, M: M}).vector(x); err != nil {
+			err = fmt.Errorf("rigid mode %d: %v", i, err)
+			return
+		}
+	}
+	return
+}
+
+// Собственные колебания незакрепленной конструкции.
+// Возвращает формы жесткого смещения и nev низших упругих форм,
+// все формы нормированы по матрице масс.
+func freeVibration(K, M [][]float64, nev int) (rigid, elastic []eigen, err error) {
+	if err = checkSymmetric(M); err != nil {
+		return
+	}
+	if rigid, err = rigidModes(K, M); err != nil {
+		return
+	}
+	n := len(K)
+	if nev <= 0 || nev > n-len(rigid) {
+		nev = n - len(rigid)
+	}
+	if output {
+		fmt.Printf("rigid modes: %d\n", len(rigid))
+	}
+	if nev == 0 {
+		return
+	}
+
+	L, err := cholesky(M)
+	if err != nil {
+		err = fmt.Errorf("mass matrix: %v", err)
+		return
+	}
+
+	σ := rigidShift
+	if σ == 0.0 {
+		σ = -0.01 * normInf(K) / normInf(M)
+	}
+	if σ >= 0.0 {
+		err = fmt.Errorf("shift is not negative: %.5e", σ)
+		return
+	}
+	A := make([][]float64, n)
+	for i := range A {
+		A[i] = make([]float64, n)
+		for j := range A[i] {
+			A[i][j] = K[i][j] - σ*M[i][j]
+		}
+	}
+	f, err := luFactorize(A, 0.0)
+	if err != nil {
+		err = fmt.Errorf("shifted stiffness matrix: %v", err)
+		return
+	}
+
+	// формы жесткого смещения в координатах y = Lᵀ·x
+	var Y [][]float64
+	for i := range rigid {
+		y := make([]float64, n)
+		for row := range y {
+			for k := row; k < n; k++ {
+				y[row] += L[k][row] * rigid[i].𝑿[k]
+			}
+		}
+		Y = append(Y, y)
+	}
+
+	op := &rigidOperator{L: L, f: f, Y: Y, t: make([]float64, n)}
+	e, err := exhOperator(op, nev)
+	if err != nil {
+		return
+	}
+
+	nz := normalizer{mode: massNorm, M: M, sign: true}
+	for i := range e {
+		x := upperSolve(L, e[i].𝑿)
+		if err = nz.vector(x); err != nil {
+			return
+		}
+		elastic = append(elastic, eigen{𝑿: x, 𝜦: σ + 1.0/e[i].𝜦})
+	}
+	sort.SliceStable(elastic, func(i, j int) bool {
+		return elastic[i].𝜦 < elastic[j].𝜦
+	})
+	return
+}
+
+// оператор P·C·P, P = I - Σ y·yᵀ - проекция без форм
+// жесткого смещения
+type rigidOperator struct {
+	L [][]float64
+	f lu
+	Y [][]float64
+	t []float64
+}
+
+func (r *rigidOperator) size() int {
+	return len(r.L)
+}
+
+func (r *rigidOperator) project(x []float64) {
+	for _, y := range r.Y {
+		var xy float64
+		for i := range x {
+			xy += x[i] * y[i]
+		}
+		for i := range x {
+			x[i] -= xy * y[i]
+		}
+	}
+}
+
+func (r *rigidOperator) mul(y, x []float64) {
+	n := len(x)
+	copy(r.t, x)
+	r.project(r.t)
+
+	// L·x
+	Lx := make([]float64, n)
+	for row := 0; row < n; row++ {
+		for k := 0; k <= row; k++ {
+			Lx[row] += r.L[row][k] * r.t[k]
+		}
+	}
+	z := r.f.solve(Lx)
+
+	// Lᵀ·z
+	for row := 0; row < n; row++ {
+		y[row] = 0.0
+		for k := row; k < n; k++ {
+			y[row] += r.L[k][row] * z[k]
+		}
+	}
+	r.project(y)
+}
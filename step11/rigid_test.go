@@ -0,0 +1,108 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFreeVibration(t *testing.T) {
+	defer harmonicStart()()
+
+	// свободная цепочка m - k - m - k - m:
+	// λ = 0, k/m, 3k/m
+	k, m := 100.0, 2.0
+	K := [][]float64{
+		{k, -k, 0},
+		{-k, 2 * k, -k},
+		{0, -k, k},
+	}
+	M := [][]float64{
+		{m, 0, 0},
+		{0, m, 0},
+		{0, 0, m},
+	}
+
+	rigid, elastic, err := freeVibration(K, M, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rigid) != 1 {
+		t.Fatalf("amount of rigid modes: %d", len(rigid))
+	}
+	for i := range rigid[0].𝑿 {
+		if math.Abs(math.Abs(rigid[0].𝑿[i])-1/math.Sqrt(3*m)) > 1e-12 {
+			t.Errorf("rigid mode: %v", rigid[0].𝑿)
+		}
+	}
+
+	values := []float64{k / m, 3 * k / m}
+	if len(elastic) != len(values) {
+		t.Fatalf("amount of elastic modes: %d", len(elastic))
+	}
+	for i := range elastic {
+		if math.Abs(elastic[i].𝜦-values[i]) > 1e-10*values[i] {
+			t.Errorf("elastic %d: %.14e != %.14e", i, elastic[i].𝜦, values[i])
+		}
+		// ортогональность по массе к жесткому смещению
+		var xMr float64
+		for j := range elastic[i].𝑿 {
+			xMr += elastic[i].𝑿[j] * m * rigid[0].𝑿[j]
+		}
+		if math.Abs(xMr) > 1e-12 {
+			t.Errorf("elastic %d is not orthogonal to rigid mode: %v", i, xMr)
+		}
+	}
+
+	t.Run("two bodies", func(t *testing.T) {
+		// две несвязанные пары масс: 2 формы жесткого смещения
+		K := [][]float64{
+			{k, -k, 0, 0},
+			{-k, k, 0, 0},
+			{0, 0, 2 * k, -2 * k},
+			{0, 0, -2 * k, 2 * k},
+		}
+		M := [][]float64{
+			{m, 0, 0, 0},
+			{0, m, 0, 0},
+			{0, 0, m, 0},
+			{0, 0, 0, m},
+		}
+		rigid, elastic, err := freeVibration(K, M, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rigid) != 2 || len(elastic) != 1 {
+			t.Fatalf("rigid %d, elastic %d", len(rigid), len(elastic))
+		}
+		if l := 2 * k / m; math.Abs(elastic[0].𝜦-l) > 1e-10*l {
+			t.Errorf("%.14e != %.14e", elastic[0].𝜦, l)
+		}
+	})
+
+	t.Run("constrained", func(t *testing.T) {
+		rigid, elastic, err := freeVibration([][]float64{
+			{2 * k, -k},
+			{-k, k},
+		}, [][]float64{
+			{m, 0},
+			{0, m},
+		}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rigid) != 0 || len(elastic) != 2 {
+			t.Fatalf("rigid %d, elastic %d", len(rigid), len(elastic))
+		}
+	})
+
+	t.Run("positive shift", func(t *testing.T) {
+		old := rigidShift
+		defer func() {
+			rigidShift = old
+		}()
+		rigidShift = 1.0
+		if _, _, err := freeVibration(K, M, 0); err == nil {
+			t.Errorf("error is not found")
+		}
+	})
+}
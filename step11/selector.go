@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Выбор искомых собственных пар
+//
+// Метод исчерпывания находит собственные значения по убыванию
+// модуля. Другие части спектра находятся преобразованием
+// оператора так, чтобы искомые значения стали наибольшими
+// по модулю:
+//
+//	наибольшие/наименьшие алгебраические: A - σ·I, σ - граница кругов Гершгорина
+//	наименьшие по модулю, ближайшие к σ:  (A - σ·I)⁻¹, μ = 1/(λ - σ)
+//	внутри интервала [a,b]:               (A - c·I)⁻¹, c = (a+b)/2
+
+// искомая часть спектра
+type which int
+
+const (
+	// наибольшие по модулю
+	largestMagnitude which = iota
+
+	// наименьшие по модулю
+	smallestMagnitude
+
+	// наибольшие алгебраические
+	largestAlgebraicValues
+
+	// наименьшие алгебраические
+	smallestAlgebraicValues
+
+	// половина с каждого конца спектра
+	bothEnds
+
+	// ближайшие к σ
+	nearestTarget
+
+	// внутри интервала [lower, upper]
+	inInterval
+)
+
+// выбор собственных пар
+type selector struct {
+	which which
+
+	// целевое значение для nearestTarget
+	σ float64
+
+	// интервал для inInterval
+	lower, upper float64
+
+	// количество собственных пар, при nev <= 0 находятся все
+	// (для inInterval - все внутри интервала)
+	nev int
+}
+
+// Собственные пары по выбору. Результат упорядочен по выбору:
+// по убыванию или возрастанию модуля, по убыванию или
+// возрастанию значения, по расстоянию до σ или до середины интервала.
+func exhSelect(A [][]float64, s selector) (e []eigen, err error) {
+	if err = checkInput(A); err != nil {
+		return
+	}
+	n := len(A)
+	nev := s.nev
+	if nev <= 0 || nev > n {
+		nev = n
+	}
+
+	switch s.which {
+	case largestMagnitude:
+		e, err = exhOperator(dense(A), nev)
+
+	case smallestMagnitude:
+		e, err = exhNearest(A, 0.0, nev, nil)
+
+	case nearestTarget:
+		e, err = exhNearest(A, s.σ, nev, nil)
+
+	case inInterval:
+		if !(s.lower <= s.upper) {
+			err = fmt.Errorf("interval is not valid: [%.5e, %.5e]", s.lower, s.upper)
+			return
+		}
+		c := (s.lower + s.upper) / 2.0
+		half := (s.upper - s.lower) / 2.0
+		e, err = exhNearest(A, c, nev, func(l float64) bool {
+			return math.Abs(l-c) > half*(1+𝛆*100)+𝛆*100*math.Abs(c)
+		})
+
+	case largestAlgebraicValues:
+		e, err = exhEnd(A, largestAlgebraic, nev)
+
+	case smallestAlgebraicValues:
+		e, err = exhEnd(A, smallestAlgebraic, nev)
+
+	case bothEnds:
+		var lower []eigen
+		if e, err = exhEnd(A, largestAlgebraic, nev-nev/2); err != nil {
+			return
+		}
+		if nev/2 > 0 {
+			if lower, err = exhEnd(A, smallestAlgebraic, nev/2); err != nil {
+				return
+			}
+		}
+		e = append(e, lower...)
+
+	default:
+		err = fmt.Errorf("selector %d is not supported", s.which)
+	}
+	if err != nil {
+		return
+	}
+
+	if refinement {
+		if err = refine(A, e); err != nil {
+			return
+		}
+	}
+	if err = debugBounds(A, e); err != nil {
+		return
+	}
+	s.sort(e)
+	err = normalizing.apply(e)
+	return
+}
+
+// упорядочивание собственных пар по выбору
+func (s selector) sort(e []eigen) {
+	key := func(l float64) float64 {
+		switch s.which {
+		case smallestMagnitude:
+			return math.Abs(l)
+		case largestAlgebraicValues, bothEnds:
+			return -l
+		case smallestAlgebraicValues:
+			return l
+		case nearestTarget:
+			return math.Abs(l - s.σ)
+		case inInterval:
+			return math.Abs(l - (s.lower+s.upper)/2.0)
+		}
+		return -math.Abs(l)
+	}
+	sort.SliceStable(e, func(i, j int) bool {
+		return key(e[i].𝜦) < key(e[j].𝜦)
+	})
+}
+
+// Собственные значения одного конца спектра
+// по матрице со сдвигом A - σ·I
+func exhEnd(A [][]float64, end spectrumEnd, nev int) (e []eigen, err error) {
+	σ := autoShift(A, end)
+	if output {
+		fmt.Printf("shift: σ = %.14e\n", σ)
+	}
+	e, err = exhOperator(dense(shifted(A, σ)), nev)
+	for i := range e {
+		e[i].𝜦 += σ
+	}
+	return
+}
+
+// оператор (A - σ·I)⁻¹
+type shiftInvert struct {
+	f lu
+}
+
+func (s shiftInvert) size() int {
+	return len(s.f.a)
+}
+
+func (s shiftInvert) mul(y, x []float64) {
+	copy(y, s.f.solve(x))
+}
+
+func (s shiftInvert) mulT(y, x []float64) {
+	copy(y, s.f.solveT(x))
+}
+
+// Собственные значения, ближайшие к σ. Если outside
+// возвращает true, то поиск заканчивается.
+// Для σ посередине между двумя собственными значениями
+// оператор (A - σ·I)⁻¹ имеет пару ±μ, которая разделяется
+// в power. Упорядочивание выполняет exhSelect.
+func exhNearest(A [][]float64, σ float64, nev int, outside func(l float64) bool) (e []eigen, err error) {
+	// Для σ около собственного значения μ велико, поэтому
+	// симметричная матрица исчерпывается проекцией: оператор
+	// без умножения на транспонированную матрицу
+	symmetric := checkSymmetric(A) == nil
+
+	// Для σ, равного собственному значению, матрица вырождена,
+	// поэтому сдвиг немного изменяется. Для симметричной матрицы
+	// сдвиг изменяется на наименьшую величину, при которой есть
+	// разложение: тогда собственные значения на одинаковом
+	// расстоянии от σ остаются парой ±μ с точностью до ошибок
+	// округления и разделяются в power.
+	var f lu
+	shift := σ
+	scale := math.Max(math.Abs(σ), normInf(A))
+	delta := math.Sqrt(𝛆) * scale
+	if symmetric {
+		delta = 𝛆 * 100 * scale
+	}
+	for {
+		f, err = luFactorize(A, shift)
+		if err == nil {
+			break
+		}
+		if delta > math.Sqrt(𝛆)*scale {
+			return
+		}
+		shift = σ + delta
+		delta *= 10
+	}
+	if output {
+		fmt.Printf("shift-invert: σ = %.14e\n", shift)
+	}
+
+	// λ = σ + 1/μ
+	value := func(μ float64) float64 {
+		return shift + 1.0/μ
+	}
+	var stop func(e eigen) bool
+	if outside != nil {
+		stop = func(e eigen) bool {
+			return outside(value(e.𝜦))
+		}
+	}
+	var op operator = shiftInvert{f: f}
+	if symmetric {
+		op = mulFunc{n: len(A), f: shiftInvert{f: f}.mul}
+	}
+	e, err = exhOperatorUntil(op, nev, stop)
+	for i := range e {
+		e[i].𝜦 = value(e[i].𝜦)
+	}
+	return
+}
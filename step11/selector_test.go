@@ -0,0 +1,286 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestSelector(t *testing.T) {
+	defer harmonicStart()()
+
+	A := spectrum([]float64{-6, -3, -1, 0.5, 2, 4, 7})
+
+	tcs := []struct {
+		name   string
+		s      selector
+		values []float64
+	}{
+		{name: "largest magnitude", s: selector{which: largestMagnitude, nev: 3}, values: []float64{7, -6, 4}},
+		{name: "smallest magnitude", s: selector{which: smallestMagnitude, nev: 3}, values: []float64{0.5, -1, 2}},
+		{name: "largest algebraic", s: selector{which: largestAlgebraicValues, nev: 3}, values: []float64{7, 4, 2}},
+		{name: "smallest algebraic", s: selector{which: smallestAlgebraicValues, nev: 3}, values: []float64{-6, -3, -1}},
+		{name: "both ends", s: selector{which: bothEnds, nev: 3}, values: []float64{7, 4, -6}},
+		{name: "nearest", s: selector{which: nearestTarget, σ: 3.2, nev: 2}, values: []float64{4, 2}},
+		{name: "nearest eigenvalue", s: selector{which: nearestTarget, σ: -3, nev: 2}, values: []float64{-3, -1}},
+		{name: "interval", s: selector{which: inInterval, lower: -3.5, upper: 1.2}, values: []float64{-1, 0.5, -3}},
+		{name: "interval with nev", s: selector{which: inInterval, lower: -3.5, upper: 1.2, nev: 1}, values: []float64{-1}},
+		{name: "all", s: selector{which: smallestAlgebraicValues}, values: []float64{-6, -3, -1, 0.5, 2, 4, 7}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := exhSelect(A, tc.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(e) != len(tc.values) {
+				t.Fatalf("amount of eigenpairs: %d != %d", len(e), len(tc.values))
+			}
+			for i := range e {
+				if math.Abs(e[i].𝜦-tc.values[i]) > 1e-10 {
+					t.Errorf("eigenvalue %d: %.14e != %.14e", i, e[i].𝜦, tc.values[i])
+				}
+				if r := residual(A, e[i]); r > 1e-10 {
+					t.Errorf("residual %d: %v", i, r)
+				}
+			}
+		})
+	}
+
+	t.Run("nonsymmetric", func(t *testing.T) {
+		// собственные значения 3 и -3 одинаковые по модулю
+		B := [][]float64{
+			{-3, 0},
+			{1, 3},
+		}
+		for _, s := range []selector{
+			{which: largestAlgebraicValues},
+			{which: nearestTarget, σ: 1},
+		} {
+			e, err := exhSelect(B, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(e) != 2 {
+				t.Fatalf("amount of eigenpairs: %d", len(e))
+			}
+			if math.Abs(e[0].𝜦-3) > 1e-10 || math.Abs(e[1].𝜦+3) > 1e-10 {
+				t.Errorf("%v", e)
+			}
+			for i := range e {
+				if r := residual(B, e[i]); r > 1e-10 {
+					t.Errorf("residual %d: %v", i, r)
+				}
+			}
+		}
+	})
+
+	t.Run("sign-alternating pair", func(t *testing.T) {
+		D := [][]float64{
+			{-3, 0, 0},
+			{0, 3, 0},
+			{0, 0, 1},
+		}
+		e, err := exhSelect(D, selector{which: largestMagnitude, nev: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(e) != 3 {
+			t.Fatalf("amount of eigenpairs: %d", len(e))
+		}
+		if math.Abs(math.Abs(e[0].𝜦)-3) > 1e-12 || math.Abs(e[0].𝜦+e[1].𝜦) > 1e-12 ||
+			math.Abs(e[2].𝜦-1) > 1e-12 {
+			t.Errorf("%v", e)
+		}
+		for i := range e {
+			if r := residual(D, e[i]); r > 1e-12 {
+				t.Errorf("residual %d: %v", i, r)
+			}
+		}
+
+		// исчерпывание с уменьшением размера матрицы
+		for _, method := range []deflationMethod{hotelling, wielandt, householder, implicit} {
+			oldDeflation := deflation
+			deflation = method
+			e, err := exh(D)
+			deflation = oldDeflation
+			if err != nil {
+				t.Fatalf("deflation %d: %v", method, err)
+			}
+			if len(e) != 3 || math.Abs(e[0].𝜦+e[1].𝜦) > 1e-12 {
+				t.Errorf("deflation %d: %v", method, e)
+			}
+		}
+
+		// исчерпывание Хотеллинга для пары без других значений
+		P := [][]float64{
+			{1, 0},
+			{0, -1},
+		}
+		if e, err = exh(P); err != nil {
+			t.Fatalf("hotelling 2x2: %v", err)
+		}
+		if len(e) != 2 || math.Abs(math.Abs(e[0].𝜦)-1) > 1e-12 || math.Abs(e[0].𝜦+e[1].𝜦) > 1e-12 {
+			t.Errorf("hotelling 2x2: %v", e)
+		}
+
+		// несимметричная матрица с левыми собственными векторами
+		B := generator([]eigen{
+			{𝜦: +5.0, 𝑿: []float64{1.0, 0.4, 0.0}},
+			{𝜦: -5.0, 𝑿: []float64{0.0, 0.2, 1.0}},
+			{𝜦: -1.0, 𝑿: []float64{1.0, 1.0, 1.0}},
+		})
+		if e, err = exhOperator(dense(B), 3); err != nil {
+			t.Fatal(err)
+		}
+		if len(e) != 3 || math.Abs(e[0].𝜦+e[1].𝜦) > 1e-8 || math.Abs(e[2].𝜦+1) > 1e-8 {
+			t.Errorf("nonsymmetric: %v", e)
+		}
+		for i := range e {
+			if r := residual(B, e[i]); r > 1e-8 {
+				t.Errorf("nonsymmetric residual %d: %v", i, r)
+			}
+		}
+	})
+
+	t.Run("equidistant", func(t *testing.T) {
+		// σ или середина интервала на одинаковом расстоянии от двух
+		// собственных значений дает пару ±μ для (A - σ·I)⁻¹
+		D := [][]float64{
+			{1, 0, 0, 0},
+			{0, 2, 0, 0},
+			{0, 0, 3, 0},
+			{0, 0, 0, 4},
+		}
+		tcs := []struct {
+			name   string
+			s      selector
+			values []float64
+		}{
+			{name: "nearest 2.5", s: selector{which: nearestTarget, σ: 2.5, nev: 2}, values: []float64{2, 3}},
+			{name: "nearest 3.5", s: selector{which: nearestTarget, σ: 3.5, nev: 2}, values: []float64{3, 4}},
+			{name: "interval", s: selector{which: inInterval, lower: 1.5, upper: 3.5}, values: []float64{2, 3}},
+		}
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				e, err := exhSelect(D, tc.s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(e) != len(tc.values) {
+					t.Fatalf("amount of eigenpairs: %d != %d", len(e), len(tc.values))
+				}
+				// одинаковое расстояние - порядок пары не определен
+				got := make([]float64, len(e))
+				for i := range e {
+					got[i] = e[i].𝜦
+					if r := residual(D, e[i]); r > 1e-10 {
+						t.Errorf("residual %d: %v", i, r)
+					}
+				}
+				sort.Float64s(got)
+				for i := range got {
+					if math.Abs(got[i]-tc.values[i]) > 1e-10 {
+						t.Errorf("eigenvalue %d: %.14e != %.14e", i, got[i], tc.values[i])
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("order", func(t *testing.T) {
+		// пары находятся не по убыванию модуля, результат упорядочен
+		B := generator([]eigen{
+			{𝜦: +5.0, 𝑿: []float64{0.5, 0.2, 1.0}},
+			{𝜦: +5.0, 𝑿: []float64{0.6, 1.0, 1.0}},
+			{𝜦: -1.0, 𝑿: []float64{1.0, 1.0, 1.0}},
+		})
+		for _, method := range []deflationMethod{hotelling, implicit} {
+			oldDeflation := deflation
+			deflation = method
+			e, err := exh(B)
+			deflation = oldDeflation
+			if err != nil {
+				t.Fatalf("deflation %d: %v", method, err)
+			}
+			for i := 1; i < len(e); i++ {
+				if math.Abs(e[i-1].𝜦)+𝛆 < math.Abs(e[i].𝜦) {
+					t.Errorf("deflation %d: |%.14e| < |%.14e|", method, e[i-1].𝜦, e[i].𝜦)
+				}
+			}
+		}
+	})
+
+	t.Run("target on eigenvalue", func(t *testing.T) {
+		// σ или середина интервала совпадает с собственным значением,
+		// собственные значения 2, 2 ± 1.2541016, 2 ± 2.7461575
+		S := [][]float64{
+			{4, 1, 0, 0, 0},
+			{1, 3, 1, 0, 0},
+			{0, 1, 2, 1, 0},
+			{0, 0, 1, 1, 1},
+			{0, 0, 0, 1, 0},
+		}
+		ej, err := jacobi(S, cyclicSweep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tcs := []struct {
+			name string
+			s    selector
+			nev  int
+			c    float64
+		}{
+			{name: "nearest 2", s: selector{which: nearestTarget, σ: 2.0, nev: 3}, nev: 3, c: 2.0},
+			{name: "nearest largest", s: selector{which: nearestTarget, σ: 4.7461575456, nev: 2}, nev: 2, c: 4.7461575456},
+			{name: "interval", s: selector{which: inInterval, lower: 0.5, upper: 3.5}, nev: 3, c: 2.0},
+		}
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				e, err := exhSelect(S, tc.s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(e) != tc.nev {
+					t.Fatalf("amount of eigenpairs: %d != %d", len(e), tc.nev)
+				}
+
+				// ожидаемые значения - ближайшие к c по Якоби
+				values := make([]float64, len(ej))
+				for i := range ej {
+					values[i] = ej[i].𝜦
+				}
+				sort.Slice(values, func(i, j int) bool {
+					return math.Abs(values[i]-tc.c) < math.Abs(values[j]-tc.c)
+				})
+				values = values[:tc.nev]
+				sort.Float64s(values)
+
+				got := make([]float64, len(e))
+				for i := range e {
+					got[i] = e[i].𝜦
+					if r := residual(S, e[i]); r > 1e-10 {
+						t.Errorf("residual %d: %v", i, r)
+					}
+				}
+				sort.Float64s(got)
+				for i := range got {
+					if math.Abs(got[i]-values[i]) > 1e-10 {
+						t.Errorf("eigenvalue %d: %.14e != %.14e", i, got[i], values[i])
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, s := range []selector{
+			{which: inInterval, lower: 1, upper: -1},
+			{which: which(100)},
+		} {
+			if _, err := exhSelect(A, s); err == nil {
+				t.Errorf("%v: error is not found", s)
+			}
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Автоматический сдвиг спектра
+//
+// Степенной метод не сходится, если |λmax| = |λmin|.
+// Все собственные значения лежат внутри кругов Гершгорина:
+//
+//	|λ - A[i][i]| <= Σ |A[i][j]|, j != i
+//
+// Для сдвига σ, равного нижней границе кругов, все собственные
+// значения A - σ·I неотрицательны и наибольшее по величине
+// собственное значение доминирует. Для верхней границы
+// доминирует наименьшее собственное значение.
+//
+//	(A - σ·I)·x = (λ - σ)·x
+
+// искомый конец спектра
+type spectrumEnd int
+
+const (
+	// наибольшее собственное значение
+	largestAlgebraic spectrumEnd = iota
+
+	// наименьшее собственное значение
+	smallestAlgebraic
+)
+
+// автоматический сдвиг в решателях pm и exh
+type shiftMode int
+
+const (
+	// без сдвига
+	noShift shiftMode = iota
+
+	// искомое значение наибольшее
+	shiftToLargest
+
+	// искомое значение наименьшее
+	shiftToSmallest
+)
+
+var shifting shiftMode = noShift
+
+// Сдвиг матрицы по настройке shifting. Собственные значения
+// результата решателя увеличиваются на σ.
+func (m shiftMode) apply(A [][]float64) (B [][]float64, σ float64) {
+	if m == noShift {
+		return A, 0.0
+	}
+	end := largestAlgebraic
+	if m == shiftToSmallest {
+		end = smallestAlgebraic
+	}
+	σ = autoShift(A, end)
+	if output {
+		fmt.Printf("shift: σ = %.14e\n", σ)
+	}
+	return shifted(A, σ), σ
+}
+
+// нижняя и верхняя границы кругов Гершгорина
+func gershgorinBounds(A [][]float64) (lower, upper float64) {
+	b, err := spectralBounds(dense(A))
+	if err != nil {
+		return
+	}
+	return b.interval()
+}
+
+// Сдвиг для искомого конца спектра.
+// Границы немного расширяются, чтобы собственное значение
+// на границе кругов не стало нулевым после сдвига.
+func autoShift(A [][]float64, end spectrumEnd) (σ float64) {
+	lower, upper := gershgorinBounds(A)
+	margin := (upper - lower) / 100.0
+	if margin == 0.0 {
+		margin = 1.0
+	}
+	if end == smallestAlgebraic {
+		return upper + margin
+	}
+	return lower - margin
+}
+
+// A - σ·I
+func shifted(A [][]float64, σ float64) (B [][]float64) {
+	B = make([][]float64, len(A))
+	for row := range A {
+		B[row] = make([]float64, len(A[row]))
+		copy(B[row], A[row])
+		B[row][row] -= σ
+	}
+	return
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestShift(t *testing.T) {
+	defer harmonicStart()()
+
+	tcs := []struct {
+		name   string
+		A      [][]float64
+		values []float64 // по убыванию
+	}{
+		{
+			name: "No dominant: 1",
+			A: [][]float64{
+				{1, 0},
+				{0, -1},
+			},
+			values: []float64{1, -1},
+		},
+		{
+			name: "No dominant: 3",
+			A: [][]float64{
+				{-3, 0},
+				{1, 3},
+			},
+			values: []float64{3, -3},
+		},
+		{
+			name: "Нет доминантной l1 = - l2",
+			A: generator([]eigen{
+				{𝜦: +5.0, 𝑿: []float64{1.0, 0.4, 0.0}},
+				{𝜦: -5.0, 𝑿: []float64{0.0, 0.2, 1.0}},
+				{𝜦: -1.0, 𝑿: []float64{1.0, 1.0, 1.0}},
+			}),
+			values: []float64{5, -1, -5},
+		},
+		{
+			name:   "symmetric",
+			A:      spectrum([]float64{4, -4, 1, 0.5, -2}),
+			values: []float64{4, 1, 0.5, -2, -4},
+		},
+		{
+			name: "identity",
+			A: [][]float64{
+				{2, 0},
+				{0, 2},
+			},
+			values: []float64{2, 2},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			n := len(tc.values)
+			oldShifting := shifting
+			defer func() {
+				shifting = oldShifting
+			}()
+
+			for _, mode := range []shiftMode{shiftToLargest, shiftToSmallest} {
+				expect := tc.values[0]
+				if mode == shiftToSmallest {
+					expect = tc.values[n-1]
+				}
+				shifting = mode
+				es, err := pm(tc.A)
+				if err != nil {
+					t.Fatal(err)
+				}
+				e := es[0]
+				if math.Abs(e.𝜦-expect) > 1e-10 {
+					t.Errorf("pm. shift %d: %.14e != %.14e", mode, e.𝜦, expect)
+				}
+				if d := residual(tc.A, e); d > 1e-6 {
+					t.Errorf("pm. shift %d. residual: %.5e", mode, d)
+				}
+			}
+
+			oldDeflation := deflation
+			deflation = implicit
+			defer func() {
+				deflation = oldDeflation
+			}()
+
+			shifting = shiftToLargest
+			e, err := exh(tc.A)
+			PrintEigens(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(e) != n {
+				t.Fatalf("amount of eigenvalues: %d != %d", len(e), n)
+			}
+			for i := range e {
+				if math.Abs(e[i].𝜦-tc.values[i]) > 1e-10 {
+					t.Errorf("exh. eigenvalue %d: %.14e != %.14e", i, e[i].𝜦, tc.values[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Расчет по спектру ответа
+//
+// Наибольшее перемещение по форме i при движении основания
+// в направлении r:
+//
+//	u(i) = φ(i)·Γ(i)·Sa(T(i)) / ω(i)², Γ(i) = φ(i)ᵀ·M·r
+//
+// Модальные ответы объединяются:
+//
+//	SRSS: u = √(Σ u(i)²)
+//	CQC:  u = √(Σ Σ ρ(i,j)·u(i)·u(j))
+//
+// Коэффициент корреляции Дер Киурегяна, r = ω(j)/ω(i):
+//
+//	ρ = 8·√(ζi·ζj)·(ζi + r·ζj)·r^(3/2) /
+//	    ((1 - r²)² + 4·ζi·ζj·r·(1 + r²) + 4·(ζi² + ζj²)·r²)
+//
+// Спектр задан для демпфирования ζ0, для демпфирования формы ζ
+// ускорение умножается на η = √((0.05 + ζ0)/(0.05 + ζ)) >= 0.55,
+// что для ζ0 = 5% совпадает с Еврокодом 8.
+
+// табличный спектр ускорений Sa(T) для демпфирования ζ
+type spectrumTable struct {
+	T  []float64
+	Sa []float64
+	ζ  float64
+}
+
+// способ объединения модальных ответов
+type combination int
+
+const (
+	srss combination = iota
+	cqc
+)
+
+// результат по одному направлению
+type spectrumDirection struct {
+	// коэффициенты участия форм
+	Γ []float64
+
+	// вклады форм modal[форма][степень свободы]
+	modal [][]float64
+
+	// объединенные наибольшие перемещения
+	peak []float64
+}
+
+// результат расчета по спектру ответа
+type spectrumResponse struct {
+	directions []spectrumDirection
+
+	// SRSS по направлениям
+	peak []float64
+}
+
+func (s spectrumTable) check() error {
+	if len(s.T) == 0 || len(s.T) != len(s.Sa) {
+		return fmt.Errorf("spectrum table is not valid: %d periods, %d values", len(s.T), len(s.Sa))
+	}
+	for i := 1; i < len(s.T); i++ {
+		if s.T[i] <= s.T[i-1] {
+			return fmt.Errorf("periods are not increasing at %d", i)
+		}
+	}
+	return nil
+}
+
+// Sa(T) с линейной интерполяцией. Вне таблицы
+// берется крайнее значение.
+func (s spectrumTable) at(T float64) float64 {
+	n := len(s.T)
+	if T <= s.T[0] {
+		return s.Sa[0]
+	}
+	if T >= s.T[n-1] {
+		return s.Sa[n-1]
+	}
+	i := 1
+	for s.T[i] < T {
+		i++
+	}
+	w := (T - s.T[i-1]) / (s.T[i] - s.T[i-1])
+	return s.Sa[i-1] + w*(s.Sa[i]-s.Sa[i-1])
+}
+
+// поправка спектра на демпфирование
+func (s spectrumTable) correction(ζ float64) float64 {
+	return math.Max(0.55, math.Sqrt((0.05+s.ζ)/(0.05+ζ)))
+}
+
+// коэффициент корреляции Дер Киурегяна
+func correlation(ωi, ωj, ζi, ζj float64) float64 {
+	r := ωj / ωi
+	num := 8 * math.Sqrt(ζi*ζj) * (ζi + r*ζj) * math.Pow(r, 1.5)
+	den := (1-r*r)*(1-r*r) + 4*ζi*ζj*r*(1+r*r) + 4*(ζi*ζi+ζj*ζj)*r*r
+	return num / den
+}
+
+// Расчет по спектру ответа для форм modes, нормированных
+// по массе, с демпфированием ζ по формам
+func responseSpectrum(modes []eigen, M [][]float64, directions [][]float64,
+	table spectrumTable, ζ []float64, method combination) (res spectrumResponse, err error) {
+	if err = table.check(); err != nil {
+		return
+	}
+	if len(ζ) != len(modes) {
+		err = fmt.Errorf("amount of damping ratios %d is not same as modes %d", len(ζ), len(modes))
+		return
+	}
+	if method != srss && method != cqc {
+		err = fmt.Errorf("combination %d is not supported", method)
+		return
+	}
+	n := len(M)
+	ω := make([]float64, len(modes))
+	for i, m := range modes {
+		if len(m.𝑿) != n {
+			err = fmt.Errorf("size of mode %d is %d, not %d", i, len(m.𝑿), n)
+			return
+		}
+		if m.𝜦 <= 0.0 {
+			err = fmt.Errorf("eigenvalue of mode %d is not positive: %.5e", i, m.𝜦)
+			return
+		}
+		if ζ[i] <= 0.0 || 1.0 <= ζ[i] {
+			err = fmt.Errorf("damping ratio of mode %d is outside of (0, 1): %.5f", i, ζ[i])
+			return
+		}
+		ω[i] = math.Sqrt(m.𝜦)
+	}
+
+	// коэффициенты корреляции
+	ρ := make([][]float64, len(modes))
+	for i := range ρ {
+		ρ[i] = make([]float64, len(modes))
+		for j := range ρ[i] {
+			switch {
+			case i == j:
+				ρ[i][j] = 1.0
+			case method == cqc:
+				ρ[i][j] = correlation(ω[i], ω[j], ζ[i], ζ[j])
+			}
+		}
+	}
+
+	res.peak = make([]float64, n)
+	Mr := make([]float64, n)
+	for d, r := range directions {
+		if len(r) != n {
+			err = fmt.Errorf("size of direction %d is %d, not %d", d, len(r), n)
+			return
+		}
+		dense(M).mul(Mr, r)
+
+		var sd spectrumDirection
+		for i, m := range modes {
+			var Γ float64
+			for k := range Mr {
+				Γ += m.𝑿[k] * Mr[k]
+			}
+			T := 2.0 * math.Pi / ω[i]
+			Sd := table.at(T) * table.correction(ζ[i]) / m.𝜦
+			u := make([]float64, n)
+			for k := range u {
+				u[k] = m.𝑿[k] * Γ * Sd
+			}
+			sd.Γ = append(sd.Γ, Γ)
+			sd.modal = append(sd.modal, u)
+		}
+
+		sd.peak = make([]float64, n)
+		for k := 0; k < n; k++ {
+			var sum float64
+			for i := range modes {
+				for j := range modes {
+					sum += ρ[i][j] * sd.modal[i][k] * sd.modal[j][k]
+				}
+			}
+			sd.peak[k] = math.Sqrt(math.Max(sum, 0.0))
+			res.peak[k] += sd.peak[k] * sd.peak[k]
+		}
+		res.directions = append(res.directions, sd)
+	}
+	for k := range res.peak {
+		res.peak[k] = math.Sqrt(res.peak[k])
+	}
+	return
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSpectrumTable(t *testing.T) {
+	s := spectrumTable{T: []float64{0.1, 0.5, 2.0}, Sa: []float64{5, 10, 2.5}, ζ: 0.05}
+	if err := s.check(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct{ T, Sa float64 }{
+		{0.0, 5}, {0.1, 5}, {0.3, 7.5}, {0.5, 10}, {1.25, 6.25}, {3.0, 2.5},
+	} {
+		if v := s.at(tc.T); math.Abs(v-tc.Sa) > 1e-14 {
+			t.Errorf("Sa(%v) = %v != %v", tc.T, v, tc.Sa)
+		}
+	}
+	if c := s.correction(0.05); c != 1 {
+		t.Errorf("correction: %v", c)
+	}
+	if c := s.correction(0.10); math.Abs(c-math.Sqrt(10.0/15.0)) > 1e-15 {
+		t.Errorf("correction: %v", c)
+	}
+	if c := s.correction(0.9); c != 0.55 {
+		t.Errorf("correction: %v", c)
+	}
+	for _, s := range []spectrumTable{
+		{},
+		{T: []float64{1, 2}, Sa: []float64{1}},
+		{T: []float64{1, 1}, Sa: []float64{1, 1}},
+	} {
+		if err := s.check(); err == nil {
+			t.Errorf("error is not found: %v", s)
+		}
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	if ρ := correlation(10, 10, 0.05, 0.05); math.Abs(ρ-1) > 1e-15 {
+		t.Errorf("ρ(r = 1) = %v", ρ)
+	}
+	if a, b := correlation(10, 12, 0.05, 0.05), correlation(12, 10, 0.05, 0.05); math.Abs(a-b) > 1e-15 {
+		t.Errorf("ρ is not symmetric: %v != %v", a, b)
+	}
+	// для одинакового демпфирования:
+	// ρ = 8·ζ²·(1 + r)·r^(3/2) / ((1 - r²)² + 4·ζ²·r·(1 + r)²)
+	r, ζ := 0.9, 0.05
+	expect := 8 * ζ * ζ * (1 + r) * math.Pow(r, 1.5) /
+		((1-r*r)*(1-r*r) + 4*ζ*ζ*r*(1+r)*(1+r))
+	if ρ := correlation(10, 9, ζ, ζ); math.Abs(ρ-expect) > 1e-14 {
+		t.Errorf("ρ(r = 0.9) = %v != %v", ρ, expect)
+	}
+	if ρ := correlation(10, 30, 0.05, 0.05); ρ > 0.01 {
+		t.Errorf("well separated modes: ρ = %v", ρ)
+	}
+}
+
+func TestResponseSpectrum(t *testing.T) {
+	K, M := chain(3, 1000, 2, true)
+	modes, err := generalized(K, M)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ζ := []float64{0.05, 0.05, 0.05}
+	table := spectrumTable{T: []float64{0.05, 0.2, 1.0, 4.0}, Sa: []float64{4, 10, 10, 2}, ζ: 0.05}
+	r := []float64{1, 1, 1}
+
+	res, err := responseSpectrum(modes, M, [][]float64{r}, table, ζ, srss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := res.directions[0]
+
+	// вклад первой формы
+	var Γ float64
+	for k := range r {
+		Γ += modes[0].𝑿[k] * M[k][k] * r[k]
+	}
+	T := 2 * math.Pi / math.Sqrt(modes[0].𝜦)
+	for k := range r {
+		u := modes[0].𝑿[k] * Γ * table.at(T) / modes[0].𝜦
+		if math.Abs(d.modal[0][k]-u) > 1e-15 {
+			t.Errorf("modal contribution %d: %v != %v", k, d.modal[0][k], u)
+		}
+	}
+	for k := range r {
+		var sum float64
+		for i := range modes {
+			sum += d.modal[i][k] * d.modal[i][k]
+		}
+		if math.Abs(d.peak[k]-math.Sqrt(sum)) > 1e-15 || d.peak[k] != res.peak[k] {
+			t.Errorf("srss %d: %v != %v", k, d.peak[k], math.Sqrt(sum))
+		}
+	}
+
+	// для разнесенных частот CQC близко к SRSS
+	resCQC, err := responseSpectrum(modes, M, [][]float64{r}, table, ζ, cqc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := range r {
+		if math.Abs(resCQC.peak[k]-res.peak[k]) > 0.02*res.peak[k] {
+			t.Errorf("cqc %d: %v, srss %v", k, resCQC.peak[k], res.peak[k])
+		}
+	}
+
+	// два направления: SRSS по направлениям
+	res2, err := responseSpectrum(modes, M, [][]float64{r, r}, table, ζ, srss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := range r {
+		if math.Abs(res2.peak[k]-math.Sqrt(2)*res.peak[k]) > 1e-14 {
+			t.Errorf("directions %d: %v", k, res2.peak[k])
+		}
+	}
+
+	t.Run("close modes", func(t *testing.T) {
+		// две формы с одинаковой частотой: CQC - сумма модулей
+		modes := []eigen{
+			{𝜦: 100, 𝑿: []float64{1, 0}},
+			{𝜦: 100, 𝑿: []float64{0, 1}},
+		}
+		M := [][]float64{{1, 0}, {0, 1}}
+		res, err := responseSpectrum(modes, M, [][]float64{{1, 1}}, table,
+			[]float64{0.05, 0.05}, cqc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u := table.at(2*math.Pi/10) / 100
+		for k := range res.peak {
+			if math.Abs(res.peak[k]-u) > 1e-15 {
+				t.Errorf("%v != %v", res.peak[k], u)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			ζ      []float64
+			r      []float64
+			method combination
+			table  spectrumTable
+		}{
+			{ζ: ζ[:1], r: r, table: table},
+			{ζ: []float64{0, 0.05, 0.05}, r: r, table: table},
+			{ζ: ζ, r: []float64{1}, table: table},
+			{ζ: ζ, r: r, method: combination(5), table: table},
+			{ζ: ζ, r: r, table: spectrumTable{}},
+		} {
+			if _, err := responseSpectrum(modes, M, [][]float64{tc.r}, tc.table, tc.ζ, tc.method); err == nil {
+				t.Errorf("error is not found: %v", tc)
+			}
+		}
+	})
+}
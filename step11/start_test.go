@@ -0,0 +1,17 @@
+package main
+
+// Детерминированный начальный вектор x[i] = 1/(1+i) вместо
+// случайного. Возвращает функцию восстановления:
+//
+//	defer harmonicStart()()
+func harmonicStart() (restore func()) {
+	old := initialize
+	initialize = func(x []float64) {
+		for i := range x {
+			x[i] = 1.0 / (1.0 + float64(i))
+		}
+	}
+	return func() {
+		initialize = old
+	}
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Динамический расчет методом разложения по формам
+//
+//	M·ẍ + C·ẋ + K·x = f·s(t)
+//
+// Для форм, нормированных по массе, x = Σ φ·q и каждое
+// модальное уравнение независимо:
+//
+//	q̈ + 2·ζ·ω·q̇ + ω²·q = φᵀ·f·s(t)
+//
+// Нагрузка s(t) задана значениями с шагом Δt и линейна между
+// ними, поэтому интеграл Дюамеля на шаге вычисляется точно
+// (рекуррентные формулы Нигама-Дженнингса).
+//
+// Литература:
+// * Chopra A.K. Dynamics of structures. Chapter 5.2
+
+// история нагрузки: s(k·dt) = values[k]
+type loadHistory struct {
+	dt     float64
+	values []float64
+}
+
+// результат динамического расчета
+type transient struct {
+	// время
+	t []float64
+
+	// модальные координаты q[форма][шаг]
+	q [][]float64
+
+	// перемещения x[степень свободы из списка][шаг]
+	x [][]float64
+}
+
+func (l loadHistory) check() error {
+	if l.dt <= 0.0 {
+		return fmt.Errorf("time step is not positive: %.5e", l.dt)
+	}
+	if len(l.values) < 2 {
+		return fmt.Errorf("load history has not enough values: %d", len(l.values))
+	}
+	return nil
+}
+
+// Перемещения степеней свободы dofs по формам modes
+// с коэффициентами демпфирования ζ при нагрузке f·s(t)
+func modalResponse(modes []eigen, ζ []float64, f []float64, load loadHistory, dofs []int) (res transient, err error) {
+	if err = load.check(); err != nil {
+		return
+	}
+	if len(ζ) != len(modes) {
+		err = fmt.Errorf("amount of damping ratios %d is not same as modes %d", len(ζ), len(modes))
+		return
+	}
+	steps := len(load.values)
+	res.t = make([]float64, steps)
+	for k := range res.t {
+		res.t[k] = float64(k) * load.dt
+	}
+
+	// Формы жесткого смещения находятся с λ ≈ 0 на уровне ошибок
+	// округления наибольшего λ, а формулы для ω > 0 неустойчивы
+	// при малом ω. Поэтому такие формы считаются жесткими.
+	var λmax float64
+	for _, m := range modes {
+		λmax = math.Max(λmax, math.Abs(m.𝜦))
+	}
+	rigid := math.Sqrt(𝛆) * λmax
+
+	for i, m := range modes {
+		if len(m.𝑿) != len(f) {
+			err = fmt.Errorf("size of mode %d is %d, not %d", i, len(m.𝑿), len(f))
+			return
+		}
+		if m.𝜦 < -rigid {
+			err = fmt.Errorf("eigenvalue of mode %d is negative: %.5e", i, m.𝜦)
+			return
+		}
+		if ζ[i] < 0.0 || 1.0 <= ζ[i] {
+			err = fmt.Errorf("damping ratio of mode %d is outside of [0, 1): %.5f", i, ζ[i])
+			return
+		}
+		var Γ float64 // φᵀ·f
+		for k := range f {
+			Γ += m.𝑿[k] * f[k]
+		}
+		p := make([]float64, steps)
+		for k := range p {
+			p[k] = Γ * load.values[k]
+		}
+		ω := 0.0
+		if m.𝜦 > rigid {
+			ω = math.Sqrt(m.𝜦)
+		}
+		res.q = append(res.q, duhamel(ω, ζ[i], load.dt, p))
+	}
+
+	for _, d := range dofs {
+		if d < 0 || len(f) <= d {
+			err = fmt.Errorf("dof %d is outside of model", d)
+			return
+		}
+		x := make([]float64, steps)
+		for i, m := range modes {
+			for k := range x {
+				x[k] += m.𝑿[d] * res.q[i][k]
+			}
+		}
+		res.x = append(res.x, x)
+	}
+	return
+}
+
+// Точное решение q̈ + 2·ζ·ω·q̇ + ω²·q = p(t) для кусочно-линейной p
+// с нулевыми начальными условиями. Для формы жесткого смещения
+// ω = 0, см. modalResponse.
+func duhamel(ω, ζ, dt float64, p []float64) (q []float64) {
+	q = make([]float64, len(p))
+	var v float64
+
+	// форма движения как жесткого целого: q̈ = p
+	if ω == 0.0 {
+		for k := 0; k+1 < len(p); k++ {
+			q[k+1] = q[k] + v*dt + dt*dt*(p[k]/3.0+p[k+1]/6.0)
+			v += dt * (p[k] + p[k+1]) / 2.0
+		}
+		return
+	}
+
+	k := ω * ω
+	ωD := ω * math.Sqrt(1.0-ζ*ζ)
+	r := ζ / math.Sqrt(1.0-ζ*ζ)
+	e := math.Exp(-ζ * ω * dt)
+	s := math.Sin(ωD * dt)
+	c := math.Cos(ωD * dt)
+
+	A := e * (r*s + c)
+	B := e * s / ωD
+	C := (2*ζ/(ω*dt) + e*(((1-2*ζ*ζ)/(ωD*dt)-r)*s-(1+2*ζ/(ω*dt))*c)) / k
+	D := (1 - 2*ζ/(ω*dt) + e*((2*ζ*ζ-1)/(ωD*dt)*s+2*ζ/(ω*dt)*c)) / k
+
+	A1 := -e * ω / math.Sqrt(1.0-ζ*ζ) * s
+	B1 := e * (c - r*s)
+	C1 := (-1/dt + e*((ω/math.Sqrt(1.0-ζ*ζ)+r/dt)*s+c/dt)) / k
+	D1 := (1 - e*(r*s+c)) / (k * dt)
+
+	for i := 0; i+1 < len(p); i++ {
+		q[i+1] = A*q[i] + B*v + C*p[i] + D*p[i+1]
+		v = A1*q[i] + B1*v + C1*p[i] + D1*p[i+1]
+	}
+	return
+}
+
+// Матрица демпфирования по модальным коэффициентам для полного
+// набора форм, нормированных по массе:
+//
+//	C = M·Φ·diag(2·ζ·ω)·Φᵀ·M
+func modalDamping(M [][]float64, modes []eigen, ζ []float64) (C [][]float64) {
+	n := len(M)
+	C = zeros(n)
+	Mφ := make([]float64, n)
+	for i, m := range modes {
+		dense(M).mul(Mφ, m.𝑿)
+		c := 2.0 * ζ[i] * math.Sqrt(math.Max(m.𝜦, 0.0))
+		for a := 0; a < n; a++ {
+			for b := 0; b < n; b++ {
+				C[a][b] += c * Mφ[a] * Mφ[b]
+			}
+		}
+	}
+	return
+}
+
+// Прямое интегрирование по методу Ньюмарка со средним
+// ускорением (γ = 1/2, β = 1/4) с нулевыми начальными условиями
+func newmark(K, C, M [][]float64, f []float64, load loadHistory, dofs []int) (res transient, err error) {
+	if err = load.check(); err != nil {
+		return
+	}
+	n := len(K)
+	if len(C) != n || len(M) != n || len(f) != n {
+		err = fmt.Errorf("size of matrices and load is not same")
+		return
+	}
+	const γ, β = 0.5, 0.25
+	dt := load.dt
+	steps := len(load.values)
+
+	Keff := zeros(n)
+	for i := range Keff {
+		for j := range Keff[i] {
+			Keff[i][j] = K[i][j] + γ/(β*dt)*C[i][j] + M[i][j]/(β*dt*dt)
+		}
+	}
+	fK, err := luFactorize(Keff, 0.0)
+	if err != nil {
+		return
+	}
+	fM, err := luFactorize(M, 0.0)
+	if err != nil {
+		err = fmt.Errorf("mass matrix: %v", err)
+		return
+	}
+
+	u := make([]float64, n)
+	v := make([]float64, n)
+	p := make([]float64, n)
+	for i := range p {
+		p[i] = f[i] * load.values[0]
+	}
+	a := fM.solve(p)
+
+	res.t = make([]float64, steps)
+	res.x = make([][]float64, len(dofs))
+	for j, d := range dofs {
+		if d < 0 || n <= d {
+			err = fmt.Errorf("dof %d is outside of model", d)
+			return
+		}
+		res.x[j] = make([]float64, steps)
+	}
+
+	tm := make([]float64, n)
+	tc := make([]float64, n)
+	Mt := make([]float64, n)
+	Ct := make([]float64, n)
+	for k := 1; k < steps; k++ {
+		res.t[k] = float64(k) * dt
+		for i := range tm {
+			tm[i] = u[i]/(β*dt*dt) + v[i]/(β*dt) + (1/(2*β)-1)*a[i]
+			tc[i] = γ/(β*dt)*u[i] + (γ/β-1)*v[i] + dt*(γ/(2*β)-1)*a[i]
+		}
+		dense(M).mul(Mt, tm)
+		dense(C).mul(Ct, tc)
+		for i := range p {
+			p[i] = f[i]*load.values[k] + Mt[i] + Ct[i]
+		}
+		uNew := fK.solve(p)
+		for i := range u {
+			vNew := γ/(β*dt)*(uNew[i]-u[i]) + (1-γ/β)*v[i] + dt*(1-γ/(2*β))*a[i]
+			a[i] = (uNew[i]-u[i])/(β*dt*dt) - v[i]/(β*dt) - (1/(2*β)-1)*a[i]
+			v[i] = vNew
+		}
+		u = uNew
+		for j, d := range dofs {
+			res.x[j][k] = u[d]
+		}
+	}
+	return
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDuhamel(t *testing.T) {
+	dt := 0.01
+	steps := 300
+	ω, ζ := 10.0, 0.05
+
+	// ступенчатая нагрузка
+	p := make([]float64, steps)
+	for k := range p {
+		p[k] = 1.0
+	}
+	q := duhamel(ω, ζ, dt, p)
+	ωD := ω * math.Sqrt(1-ζ*ζ)
+	for k := range q {
+		tk := float64(k) * dt
+		u := (1 - math.Exp(-ζ*ω*tk)*(math.Cos(ωD*tk)+ζ/math.Sqrt(1-ζ*ζ)*math.Sin(ωD*tk))) / (ω * ω)
+		if math.Abs(q[k]-u) > 1e-14 {
+			t.Fatalf("step load, step %d: %.14e != %.14e", k, q[k], u)
+		}
+	}
+
+	// линейная нагрузка p = t без демпфирования
+	for k := range p {
+		p[k] = float64(k) * dt
+	}
+	q = duhamel(ω, 0, dt, p)
+	for k := range q {
+		tk := float64(k) * dt
+		u := (tk - math.Sin(ω*tk)/ω) / (ω * ω)
+		if math.Abs(q[k]-u) > 1e-14 {
+			t.Fatalf("ramp load, step %d: %.14e != %.14e", k, q[k], u)
+		}
+	}
+
+	// жесткое смещение: q̈ = t, q = t³/6
+	q = duhamel(0, 0, dt, p)
+	for k := range q {
+		tk := float64(k) * dt
+		if u := tk * tk * tk / 6; math.Abs(q[k]-u) > 1e-12 {
+			t.Fatalf("rigid body, step %d: %.14e != %.14e", k, q[k], u)
+		}
+	}
+}
+
+func TestModalResponse(t *testing.T) {
+	K, M := chain(3, 1000, 2, true)
+	modes, err := generalized(K, M)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ζ := []float64{0.02, 0.02, 0.02}
+	f := []float64{0, 0, 1}
+
+	// полусинусоидальный импульс длительностью 0.1
+	dt := 0.001
+	load := loadHistory{dt: dt}
+	for k := 0; k <= 1000; k++ {
+		tk := float64(k) * dt
+		v := 0.0
+		if tk < 0.1 {
+			v = 100 * math.Sin(math.Pi*tk/0.1)
+		}
+		load.values = append(load.values, v)
+	}
+
+	dofs := []int{0, 2}
+	res, err := modalResponse(modes, ζ, f, load, dofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	direct, err := newmark(K, modalDamping(M, modes, ζ), M, f, load, dofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j := range dofs {
+		var max, diff float64
+		for k := range res.x[j] {
+			max = math.Max(max, math.Abs(res.x[j][k]))
+			diff = math.Max(diff, math.Abs(res.x[j][k]-direct.x[j][k]))
+		}
+		t.Logf("dof %d: max %.5e, difference %.5e", dofs[j], max, diff)
+		if diff > 2e-3*max {
+			t.Errorf("dof %d: modal and Newmark results are not same", dofs[j])
+		}
+	}
+
+	// учет только первой формы
+	res1, err := modalResponse(modes[:1], ζ[:1], f, load, dofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res1.x) != 2 || len(res1.x[0]) != len(load.values) || len(res1.q) != 1 {
+		t.Errorf("size of result")
+	}
+
+	t.Run("free-free", func(t *testing.T) {
+		// m - k - m без закрепления, сила F на первой массе:
+		// x1 + x2 = F·t²/(2·m), x1 - x2 = F/(2·k)·(1 - cos ω·t), ω² = 2·k/m
+		k, m, F := 1000.0, 2.0, 10.0
+		K := [][]float64{{k, -k}, {-k, k}}
+		M := [][]float64{{m, 0}, {0, m}}
+		modes, err := generalized(K, M)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(modes[0].𝜦) > 1e-10*modes[1].𝜦 {
+			t.Fatalf("rigid mode is not found: %v", modes[0].𝜦)
+		}
+		load := loadHistory{dt: 0.001}
+		for k := 0; k <= 500; k++ {
+			load.values = append(load.values, 1.0)
+		}
+		ω := math.Sqrt(2 * k / m)
+		for _, λ := range []float64{modes[0].𝜦, 1e-13, -1e-13, 0} {
+			modes[0].𝜦 = λ
+			res, err := modalResponse(modes, []float64{0, 0}, []float64{F, 0}, load, []int{0})
+			if err != nil {
+				t.Fatalf("λ = %v: %v", λ, err)
+			}
+			for s, tk := range res.t {
+				x1 := (F*tk*tk/(2*m) + F/(2*k)*(1-math.Cos(ω*tk))) / 2
+				if math.Abs(res.x[0][s]-x1) > 1e-10*math.Max(1, math.Abs(x1)) {
+					t.Fatalf("λ = %v, step %d: %.14e != %.14e", λ, s, res.x[0][s], x1)
+				}
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			ζ    []float64
+			load loadHistory
+			dofs []int
+		}{
+			{ζ: ζ[:1], load: load},
+			{ζ: []float64{0.02, 1, 0.02}, load: load},
+			{ζ: ζ, load: loadHistory{dt: 0, values: load.values}},
+			{ζ: ζ, load: loadHistory{dt: dt, values: []float64{1}}},
+			{ζ: ζ, load: load, dofs: []int{3}},
+		} {
+			if _, err := modalResponse(modes, tc.ζ, f, tc.load, tc.dofs); err == nil {
+				t.Errorf("error is not found: %v", tc.ζ)
+			}
+		}
+		if _, err := newmark(K, K, M, f, load, []int{-1}); err == nil {
+			t.Errorf("newmark: error is not found")
+		}
+	})
+}